@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const ACAO_ATIVIDADES_COMPLEMENTARES = "menu_form_menu_discente_discente_menu:A]#{ atividadeComplementarDiscente.listarMinhasAtividades }"
+
+const (
+	ATIVIDADE_APROVADA   = "aprovada"
+	ATIVIDADE_EM_ANALISE = "em_analise"
+	ATIVIDADE_RECUSADA   = "recusada"
+)
+
+func statusAtividade(situacao string) string {
+	situacao = normalizeText(situacao)
+	switch {
+	case strings.Contains(situacao, "indeferid"), strings.Contains(situacao, "recusad"), strings.Contains(situacao, "negad"):
+		return ATIVIDADE_RECUSADA
+	case strings.Contains(situacao, "aprovad"), strings.Contains(situacao, "deferid"), strings.Contains(situacao, "validad"), strings.Contains(situacao, "homologad"):
+		return ATIVIDADE_APROVADA
+	default:
+		return ATIVIDADE_EM_ANALISE
+	}
+}
+
+// parseAtividadesComplementares lê a listagem de atividades e, se houver, o quadro de exigências por categoria.
+// As duas tabelas são reconhecidas pelos cabeçalhos: a de atividades tem situação, a de exigências tem mínimo.
+func parseAtividadesComplementares(doc *goquery.Document) ResumoAtividadesComplementares {
+	resumo := ResumoAtividadesComplementares{
+		Atividades: []AtividadeComplementar{},
+		Categorias: []TotalCategoriaAtividades{},
+	}
+
+	var categorias []string
+	totais := map[string]*TotalCategoriaAtividades{}
+	totalCategoria := func(categoria string) *TotalCategoriaAtividades {
+		chave := normalizeText(categoria)
+		if totais[chave] == nil {
+			totais[chave] = &TotalCategoriaAtividades{Categoria: categoria}
+			categorias = append(categorias, chave)
+		}
+		return totais[chave]
+	}
+
+	doc.Find("table.listagem, table.listing").Each(func(i int, table *goquery.Selection) {
+		headers := tableHeaders(table)
+		cabecalho := strings.Join(headers, "|")
+
+		table.Find("tbody tr").Each(func(j int, row *goquery.Selection) {
+			cells := row.Find("td")
+			if cells.Length() < len(headers) {
+				return
+			}
+
+			switch {
+			case strings.Contains(cabecalho, "situacao"):
+				atividade := AtividadeComplementar{
+					Descricao:    cellByHeader(headers, cells, "atividade", "descricao", "titulo"),
+					Categoria:    cellByHeader(headers, cells, "categoria", "tipo", "grupo"),
+					Periodo:      cellByHeader(headers, cells, "periodo", "ano"),
+					CargaHoraria: parseInt(cellByHeader(headers, cells, "ch", "carga")),
+					Situacao:     cellByHeader(headers, cells, "situacao"),
+				}
+				if atividade.Descricao == "" {
+					return
+				}
+				atividade.Status = statusAtividade(atividade.Situacao)
+				resumo.Atividades = append(resumo.Atividades, atividade)
+
+				total := totalCategoria(atividade.Categoria)
+				switch atividade.Status {
+				case ATIVIDADE_APROVADA:
+					total.Aprovadas += atividade.CargaHoraria
+				case ATIVIDADE_EM_ANALISE:
+					total.EmAnalise += atividade.CargaHoraria
+				}
+			case strings.Contains(cabecalho, "minim") || strings.Contains(cabecalho, "exigid"):
+				categoria := cellByHeader(headers, cells, "categoria", "tipo", "grupo")
+				if categoria == "" || strings.HasPrefix(normalizeText(categoria), "total") {
+					return
+				}
+				total := totalCategoria(categoria)
+				total.Minimo = parseInt(cellByHeader(headers, cells, "minim", "exigid"))
+				total.Maximo = parseInt(cellByHeader(headers, cells, "maxim"))
+			}
+		})
+	})
+
+	for _, chave := range categorias {
+		total := *totais[chave]
+		// Horas acima do máximo da categoria não contam para a integralização
+		total.Aproveitadas = total.Aprovadas
+		if total.Maximo > 0 && total.Aproveitadas > total.Maximo {
+			total.Aproveitadas = total.Maximo
+		}
+		if total.Minimo > total.Aproveitadas {
+			total.Restante = total.Minimo - total.Aproveitadas
+		}
+		resumo.TotalAproveitado += total.Aproveitadas
+		resumo.TotalExigido += total.Minimo
+		resumo.Categorias = append(resumo.Categorias, total)
+	}
+
+	return resumo
+}
+
+// GetAtividadesComplementares lista as atividades e cruza os totais com a CH. Complementar Pendente
+// do cabeçalho do portal
+func GetAtividadesComplementares(jsessionid string) (ResumoAtividadesComplementares, string, string, error) {
+	docPortal, jsessionid1, viewState1, err := getPaginaPortal(jsessionid)
+	if err != nil {
+		return ResumoAtividadesComplementares{}, jsessionid1, "", err
+	}
+	pendente := parseInt(parseCH(docPortal).ComplementarPendente)
+
+	doc, jsessionid2, _, err := navegarMenuPortal(ACAO_ATIVIDADES_COMPLEMENTARES, jsessionid1, viewState1)
+	if err != nil {
+		return ResumoAtividadesComplementares{}, jsessionid2, viewState1, fmt.Errorf("erro ao acessar atividades complementares: %w", err)
+	}
+
+	resumo := parseAtividadesComplementares(doc)
+	resumo.TotalPendente = pendente
+	// A soma dos mínimos por categoria pode ficar abaixo da exigência total do currículo
+	if exigido := resumo.TotalAproveitado + pendente; exigido > resumo.TotalExigido {
+		resumo.TotalExigido = exigido
+	}
+
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid2, viewState1)
+	return resumo, newJsessionid, newViewState, err
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const ACAO_AVALIACAO_INSTITUCIONAL = "menu_form_menu_discente_discente_menu:A]#{ avaliacaoInstitucional.iniciarDiscente }"
+
+// avisoAvaliacaoPendente indica se o portal do discente cobra a avaliação institucional (também conferido ao fim do login)
+func avisoAvaliacaoPendente(doc *goquery.Document) bool {
+	texto := normalizeText(doc.Find("ul.warning, ul.info, #avisos, .aviso, form").Text())
+	return strings.Contains(texto, "avaliacao institucional") &&
+		(strings.Contains(texto, "preencha") || strings.Contains(texto, "pendente") || strings.Contains(texto, "ainda nao"))
+}
+
+func formularioPreenchido(situacao string) bool {
+	situacao = normalizeText(situacao)
+	if strings.Contains(situacao, "nao") || strings.Contains(situacao, "pendente") {
+		return false
+	}
+	return strings.Contains(situacao, "preenchid") || strings.Contains(situacao, "finalizad") || strings.Contains(situacao, "respondid") || strings.Contains(situacao, "concluid")
+}
+
+// parseFormulariosAvaliacao lê a lista de formulários da avaliação (um por turma/docente e o de auto-avaliação)
+func parseFormulariosAvaliacao(doc *goquery.Document) []FormularioAvaliacao {
+	formularios := []FormularioAvaliacao{}
+
+	table := doc.Find("table.listagem, table.listing").First()
+	headers := tableHeaders(table)
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() == 1 || cells.Length() < len(headers) {
+			return
+		}
+		formulario := FormularioAvaliacao{
+			Nome:     cellByHeader(headers, cells, "formulario", "avaliacao", "questionario"),
+			Turma:    cellByHeader(headers, cells, "turma", "componente"),
+			Docente:  cellByHeader(headers, cells, "docente"),
+			Situacao: cellByHeader(headers, cells, "situacao", "status"),
+		}
+		if formulario.Nome == "" {
+			formulario.Nome = strings.Join(strings.Fields(cells.First().Text()), " ")
+		}
+		if formulario.Nome == "" {
+			return
+		}
+		formulario.Preenchido = formularioPreenchido(formulario.Situacao)
+		formularios = append(formularios, formulario)
+	})
+
+	return formularios
+}
+
+// GetStatusAvaliacaoInstitucional combina o aviso do portal com a lista de formulários da avaliação.
+// Fora do período, o SIGAA recusa a entrada com uma mensagem, e a avaliação é dada como fechada.
+func GetStatusAvaliacaoInstitucional(jsessionid string) (StatusAvaliacaoInstitucional, string, string, error) {
+	status := StatusAvaliacaoInstitucional{Formularios: []FormularioAvaliacao{}, Mensagens: []string{}}
+
+	docPortal, jsessionid1, viewState1, err := getPaginaPortal(jsessionid)
+	if err != nil {
+		return status, jsessionid1, "", err
+	}
+	status.AvisoPortal = avisoAvaliacaoPendente(docPortal)
+
+	doc, jsessionid2, _, err := navegarMenuPortal(ACAO_AVALIACAO_INSTITUCIONAL, jsessionid1, viewState1)
+	if doc == nil {
+		return status, jsessionid2, viewState1, fmt.Errorf("erro ao acessar avaliação institucional: %w", err)
+	}
+
+	info, erros := parseMensagensSigaa(doc)
+	status.Mensagens = append(append(status.Mensagens, erros...), info...)
+	status.Aberta = len(erros) == 0
+	status.Periodo = parsePeriodoMatricula(doc)
+	if status.Aberta {
+		status.Formularios = parseFormulariosAvaliacao(doc)
+	}
+
+	status.Total = len(status.Formularios)
+	for _, formulario := range status.Formularios {
+		if formulario.Preenchido {
+			status.Preenchidos++
+		}
+	}
+	status.Pendente = status.Aberta && (status.Preenchidos < status.Total || (status.Total == 0 && status.AvisoPortal))
+
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid2, viewState1)
+	return status, newJsessionid, newViewState, err
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	URL_ACERVO_PUBLICO = "https://sigs.ufrpe.br/sigaa/public/biblioteca/buscaPublicaAcervo.jsf"
+
+	// A tela de renovação lista todos os empréstimos ativos, com checkbox só nos renováveis
+	ACAO_RENOVAR_EMPRESTIMOS = "menu_form_menu_discente_discente_menu:A]#{ meusEmprestimosBibliotecaMBean.iniciarVisualizarEmprestimosRenovaveis }"
+)
+
+// parseEmprestimos lê a listagem de empréstimos; título, autor e código de barras vêm juntos na coluna do material
+func parseEmprestimos(doc *goquery.Document) []EmprestimoBiblioteca {
+	emprestimos := []EmprestimoBiblioteca{}
+
+	table := doc.Find("table.listagem, table.listing").First()
+	headers := tableHeaders(table)
+	agora := time.Now()
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() == 1 || cells.Length() < len(headers) {
+			return
+		}
+
+		emprestimo := EmprestimoBiblioteca{
+			Biblioteca:     cellByHeader(headers, cells, "biblioteca"),
+			DataEmprestimo: cellByHeader(headers, cells, "data do emprestimo", "emprestimo"),
+			PrazoDevolucao: cellByHeader(headers, cells, "prazo", "devolucao"),
+			Renovacoes:     parseInt(cellByHeader(headers, cells, "renovac")),
+			Multa:          cellByHeader(headers, cells, "multa"),
+		}
+
+		for j, header := range headers {
+			if !strings.Contains(header, "material") && !strings.Contains(header, "titulo") {
+				continue
+			}
+			for _, linha := range textLines(cells.Eq(j)) {
+				chave, valor, ok := strings.Cut(linha, ":")
+				if !ok {
+					if emprestimo.Titulo == "" {
+						emprestimo.Titulo = linha
+					}
+					continue
+				}
+				valor = strings.TrimSpace(valor)
+				switch normalizeText(chave) {
+				case "titulo":
+					emprestimo.Titulo = valor
+				case "autor":
+					emprestimo.Autor = valor
+				case "codigo de barras", "codigo":
+					emprestimo.CodigoBarras = valor
+				}
+			}
+			break
+		}
+		if emprestimo.Titulo == "" {
+			return
+		}
+
+		if checkbox := row.Find("input[type='checkbox']").First(); checkbox.Length() > 0 {
+			_, disabled := checkbox.Attr("disabled")
+			emprestimo.Renovavel = !disabled
+			emprestimo.checkbox = checkbox.AttrOr("name", "")
+			emprestimo.valorCheckbox = checkbox.AttrOr("value", "on")
+		}
+		if prazo, ok := parseDataSigaa(emprestimo.PrazoDevolucao); ok {
+			// Sem hora, o material pode ser devolvido até o fim do dia do prazo
+			if !strings.Contains(emprestimo.PrazoDevolucao, ":") {
+				prazo = prazo.Add(24*time.Hour - time.Second)
+			}
+			emprestimo.Atrasado = agora.After(prazo)
+		}
+
+		emprestimo.Id = emprestimo.CodigoBarras
+		if emprestimo.Id == "" {
+			emprestimo.Id = fmt.Sprintf("%d", i)
+		}
+		emprestimos = append(emprestimos, emprestimo)
+	})
+
+	return emprestimos
+}
+
+func GetEmprestimos(jsessionid string, viewState string) ([]EmprestimoBiblioteca, string, string, error) {
+	doc, jsessionid1, _, err := navegarMenuPortal(ACAO_RENOVAR_EMPRESTIMOS, jsessionid, viewState)
+	if err != nil {
+		return nil, jsessionid1, viewState, fmt.Errorf("erro ao acessar empréstimos da biblioteca: %w", err)
+	}
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid1, viewState)
+	return parseEmprestimos(doc), newJsessionid, newViewState, err
+}
+
+// RenovarEmprestimos marca os empréstimos pedidos (ou, com todos, todos os renováveis) e submete a renovação.
+// Uma lista vazia sem todos é recusada, para que um corpo incompleto não renove tudo.
+func RenovarEmprestimos(ids []string, todos bool, jsessionid string, viewState string) (ResultadoRenovacao, string, string, error) {
+	resultado := ResultadoRenovacao{Renovados: []EmprestimoBiblioteca{}, Mensagens: []string{}}
+	switch {
+	case todos && len(ids) > 0:
+		return resultado, jsessionid, viewState, fmt.Errorf("%w: informe ids ou todos, não os dois", ErrRequisicaoInvalida)
+	case !todos && len(ids) == 0:
+		return resultado, jsessionid, viewState, fmt.Errorf("%w: informe os ids dos empréstimos ou todos=true", ErrRequisicaoInvalida)
+	}
+
+	doc, jsessionid1, viewState1, err := navegarMenuPortal(ACAO_RENOVAR_EMPRESTIMOS, jsessionid, viewState)
+	if err != nil {
+		return resultado, jsessionid1, viewState, fmt.Errorf("erro ao acessar renovação de empréstimos: %w", err)
+	}
+
+	pedidos := map[string]bool{}
+	for _, id := range ids {
+		pedidos[id] = true
+	}
+
+	var selecionados []EmprestimoBiblioteca
+	for _, emprestimo := range parseEmprestimos(doc) {
+		// pedidos encolhe à medida que os empréstimos são encontrados; quem decide o modo é todos
+		if !todos && !pedidos[emprestimo.Id] {
+			continue
+		}
+		if !emprestimo.Renovavel {
+			if !todos {
+				return resultado, jsessionid1, viewState, fmt.Errorf("%w: o empréstimo de %s não pode ser renovado", ErrRequisicaoInvalida, emprestimo.Titulo)
+			}
+			continue
+		}
+		selecionados = append(selecionados, emprestimo)
+		delete(pedidos, emprestimo.Id)
+	}
+	for _, id := range ids {
+		if pedidos[id] {
+			return resultado, jsessionid1, viewState, fmt.Errorf("%w: empréstimo %s não encontrado", ErrRequisicaoInvalida, id)
+		}
+	}
+	if len(selecionados) == 0 {
+		return resultado, jsessionid1, viewState, fmt.Errorf("%w: nenhum empréstimo renovável", ErrRequisicaoInvalida)
+	}
+
+	form := doc.Find("input[name='" + selecionados[0].checkbox + "']").First().Closest("form")
+	payload := formPayload(form)
+	payload.Set("javax.faces.ViewState", viewState1)
+	for _, emprestimo := range selecionados {
+		payload.Add(emprestimo.checkbox, emprestimo.valorCheckbox)
+	}
+	botao := findButtonName(form, "Renovar")
+	if botao == "" {
+		return resultado, jsessionid1, viewState, fmt.Errorf("botão 'Renovar' não encontrado na tela de renovação")
+	}
+	payload.Set(botao, form.Find("[name='"+botao+"']").AttrOr("value", "Renovar"))
+
+	docResultado, jsessionid2, err := postSigaaForm(resolveActionUrl(URL_PORTAL_DISCENTE, form.AttrOr("action", "")), jsessionid1, URL_PORTAL_DISCENTE, payload)
+	if err != nil {
+		return resultado, jsessionid1, viewState, fmt.Errorf("erro ao renovar empréstimos: %w", err)
+	}
+	info, erros := parseMensagensSigaa(docResultado)
+	if len(erros) > 0 {
+		return resultado, jsessionid2, viewState, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+	}
+	resultado.Mensagens = append(resultado.Mensagens, info...)
+
+	// O comprovante traz os novos prazos; se não vier tabela, devolve os selecionados como estavam
+	renovados := map[string]bool{}
+	for _, emprestimo := range selecionados {
+		renovados[emprestimo.Id] = true
+	}
+	for _, emprestimo := range parseEmprestimos(docResultado) {
+		if renovados[emprestimo.Id] {
+			resultado.Renovados = append(resultado.Renovados, emprestimo)
+		}
+	}
+	if len(resultado.Renovados) == 0 {
+		resultado.Renovados = selecionados
+	}
+
+	newJsessionid, newViewState, err := voltarAoPortalAposEscrita(jsessionid2, viewState)
+	return resultado, newJsessionid, newViewState, err
+}
+
+// BuscarAcervo usa a consulta pública do acervo, que não depende de login
+func BuscarAcervo(filtro FiltroAcervo) ([]ItemAcervo, error) {
+	doc, jsessionid, err := doSigaaRequest("GET", URL_ACERVO_PUBLICO, "", "", nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar consulta pública do acervo: %w", err)
+	}
+
+	form := doc.Find("input[type='text']").First().Closest("form")
+	if form.Length() == 0 {
+		return nil, fmt.Errorf("não foi possível encontrar o formulário de busca do acervo")
+	}
+	payload := formPayload(form)
+
+	// Cada critério tem um checkbox que o habilita e um campo de texto
+	criterios := map[string]string{"titulo": filtro.Titulo, "autor": filtro.Autor, "assunto": filtro.Assunto}
+	for criterio, valor := range criterios {
+		if valor == "" {
+			continue
+		}
+		form.Find("input[type='checkbox']").Each(func(i int, checkbox *goquery.Selection) {
+			if strings.Contains(normalizeText(checkbox.AttrOr("id", checkbox.AttrOr("name", ""))), criterio) {
+				payload.Set(checkbox.AttrOr("name", ""), checkbox.AttrOr("value", "on"))
+			}
+		})
+		form.Find("input[type='text']").EachWithBreak(func(i int, input *goquery.Selection) bool {
+			if strings.Contains(normalizeText(input.AttrOr("id", input.AttrOr("name", ""))), criterio) {
+				payload.Set(input.AttrOr("name", ""), valor)
+				return false
+			}
+			return true
+		})
+	}
+
+	botao := findButtonName(form, "Pesquisar")
+	if botao == "" {
+		botao = findButtonName(form, "Buscar")
+	}
+	if botao == "" {
+		return nil, fmt.Errorf("não foi possível encontrar o botão de busca do acervo")
+	}
+	payload.Set(botao, form.Find("[name='"+botao+"']").AttrOr("value", "Pesquisar"))
+
+	docResultado, _, err := postSigaaForm(resolveActionUrl(URL_ACERVO_PUBLICO, form.AttrOr("action", "")), jsessionid, URL_ACERVO_PUBLICO, payload)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar no acervo: %w", err)
+	}
+	if _, erros := parseMensagensSigaa(docResultado); len(erros) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+	}
+
+	return parseAcervo(docResultado), nil
+}
+
+func parseAcervo(doc *goquery.Document) []ItemAcervo {
+	itens := []ItemAcervo{}
+
+	table := doc.Find("table.listagem, table.listing").First()
+	headers := tableHeaders(table)
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() < len(headers) {
+			return
+		}
+		item := ItemAcervo{
+			Titulo:      cellByHeader(headers, cells, "titulo"),
+			Autor:       cellByHeader(headers, cells, "autor"),
+			Edicao:      cellByHeader(headers, cells, "edicao"),
+			Ano:         cellByHeader(headers, cells, "ano"),
+			Exemplares:  parseInt(cellByHeader(headers, cells, "exemplares", "quantidade", "qtd")),
+			Disponiveis: parseInt(cellByHeader(headers, cells, "disponiveis")),
+		}
+		if item.Titulo == "" {
+			return
+		}
+		itens = append(itens, item)
+	})
+
+	return itens
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const URL_CAIXA_POSTAL = "https://sigs.ufrpe.br/sigaa/cxpostal/caixa_postal.jsf"
+
+// Parâmetros do link de abrir mensagem que trazem o id, em ordem de preferência
+var chavesIdMensagem = []string{"idMensagem", "id", "idMsg"}
+
+func getPaginaCaixaPostal(jsessionid string) (*goquery.Document, string, string, error) {
+	doc, newJsessionid, err := doSigaaRequest("GET", URL_CAIXA_POSTAL, jsessionid, URL_PORTAL_DISCENTE, nil, "")
+	if err != nil {
+		return nil, jsessionid, "", fmt.Errorf("erro ao acessar caixa postal: %w", err)
+	}
+	viewState, err := parseViewState(doc, "caixa_postal")
+	if err != nil {
+		return nil, newJsessionid, "", err
+	}
+	return doc, newJsessionid, viewState, nil
+}
+
+// parseCaixaPostal lê a caixa de entrada; mensagens não lidas aparecem em negrito ou com classe própria
+func parseCaixaPostal(doc *goquery.Document) []MensagemCaixaPostal {
+	mensagens := []MensagemCaixaPostal{}
+
+	table := doc.Find("table.listing, table.listagem").First()
+	headers := tableHeaders(table)
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		onclick, assunto := linkAbrirLinha(row)
+		if onclick == "" || cells.Length() < len(headers) {
+			return
+		}
+
+		mensagem := MensagemCaixaPostal{
+			Remetente: cellByHeader(headers, cells, "remetente", "de"),
+			Assunto:   cellByHeader(headers, cells, "assunto"),
+			Data:      cellByHeader(headers, cells, "data", "enviada"),
+			onclick:   onclick,
+			checkbox:  row.Find("input[type='checkbox']").First().AttrOr("name", ""),
+		}
+		if mensagem.Assunto == "" {
+			mensagem.Assunto = assunto
+		}
+		classe := normalizeText(row.AttrOr("class", ""))
+		mensagem.Lida = !strings.Contains(classe, "naolida") && !strings.Contains(classe, "nao-lida") && row.Find("b, strong").Length() == 0
+
+		params := jsfParams(onclick)
+		for _, chave := range chavesIdMensagem {
+			if id := params.Get(chave); id != "" {
+				mensagem.Id = id
+				break
+			}
+		}
+		if mensagem.Id == "" {
+			mensagem.Id = fmt.Sprintf("%d", i)
+		}
+
+		mensagens = append(mensagens, mensagem)
+	})
+
+	return mensagens
+}
+
+func GetCaixaPostal(jsessionid string) ([]MensagemCaixaPostal, string, error) {
+	doc, newJsessionid, _, err := getPaginaCaixaPostal(jsessionid)
+	if err != nil {
+		return nil, newJsessionid, err
+	}
+	return parseCaixaPostal(doc), newJsessionid, nil
+}
+
+func buscarMensagem(doc *goquery.Document, mensagemId string) (MensagemCaixaPostal, error) {
+	for _, mensagem := range parseCaixaPostal(doc) {
+		if mensagem.Id == mensagemId {
+			return mensagem, nil
+		}
+	}
+	return MensagemCaixaPostal{}, fmt.Errorf("%w: mensagem %s não encontrada na caixa postal", ErrRequisicaoInvalida, mensagemId)
+}
+
+// GetMensagemCaixaPostal abre a mensagem; o próprio SIGAA a marca como lida ao abrir
+func GetMensagemCaixaPostal(mensagemId string, jsessionid string) (MensagemCaixaPostalDetalhada, string, error) {
+	var detalhe MensagemCaixaPostalDetalhada
+	doc, jsessionid1, viewState1, err := getPaginaCaixaPostal(jsessionid)
+	if err != nil {
+		return detalhe, jsessionid1, err
+	}
+	mensagem, err := buscarMensagem(doc, mensagemId)
+	if err != nil {
+		return detalhe, jsessionid1, err
+	}
+
+	docMensagem, jsessionid2, _, err := clicarLinkJsf(doc, jsfFormId(mensagem.onclick), jsfParams(mensagem.onclick), jsessionid1, viewState1, URL_CAIXA_POSTAL)
+	if err != nil {
+		return detalhe, jsessionid1, fmt.Errorf("erro ao abrir mensagem %s: %w", mensagem.Assunto, err)
+	}
+
+	detalhe = MensagemCaixaPostalDetalhada{
+		Id:        mensagem.Id,
+		Remetente: mensagem.Remetente,
+		Assunto:   mensagem.Assunto,
+		Data:      mensagem.Data,
+	}
+	docMensagem.Find("table.visualizacao tr, table.formulario tr").Each(func(i int, row *goquery.Selection) {
+		valor := row.Find("td").First()
+		switch label := strings.TrimSuffix(normalizeText(row.Find("th").First().Text()), ":"); {
+		case strings.Contains(label, "remetente"), label == "de":
+			detalhe.Remetente = strings.TrimSpace(valor.Text())
+		case strings.Contains(label, "assunto"):
+			detalhe.Assunto = strings.TrimSpace(valor.Text())
+		case strings.Contains(label, "data"):
+			detalhe.Data = strings.Join(strings.Fields(valor.Text()), " ")
+		case strings.Contains(label, "mensagem"), strings.Contains(label, "conteudo"):
+			detalhe.Conteudo = htmlToMarkdown(valor)
+		}
+	})
+	if detalhe.Conteudo == "" {
+		detalhe.Conteudo = htmlToMarkdown(docMensagem.Find(".conteudoMensagem, #conteudoMensagem").First())
+	}
+
+	return detalhe, jsessionid2, nil
+}
+
+// MarcarMensagemLida seleciona a mensagem na caixa de entrada e aciona "Marcar como lida"
+func MarcarMensagemLida(mensagemId string, jsessionid string) (string, error) {
+	doc, jsessionid1, viewState1, err := getPaginaCaixaPostal(jsessionid)
+	if err != nil {
+		return jsessionid1, err
+	}
+	mensagem, err := buscarMensagem(doc, mensagemId)
+	if err != nil {
+		return jsessionid1, err
+	}
+	if mensagem.Lida {
+		return jsessionid1, nil
+	}
+	if mensagem.checkbox == "" {
+		return jsessionid1, fmt.Errorf("a mensagem %s não pode ser selecionada", mensagem.Assunto)
+	}
+
+	checkbox := doc.Find("input[name='" + mensagem.checkbox + "']").First()
+	form := checkbox.Closest("form")
+	botao := findButtonName(form, "lida")
+	if botao == "" {
+		return jsessionid1, fmt.Errorf("botão 'Marcar como lida' não encontrado na caixa postal")
+	}
+
+	payload := formPayload(form)
+	payload.Set("javax.faces.ViewState", viewState1)
+	payload.Set(mensagem.checkbox, checkbox.AttrOr("value", "on"))
+	payload.Set(botao, form.Find("[name='"+botao+"']").AttrOr("value", ""))
+
+	docResultado, jsessionid2, err := postSigaaForm(resolveActionUrl(URL_CAIXA_POSTAL, form.AttrOr("action", "")), jsessionid1, URL_CAIXA_POSTAL, payload)
+	if err != nil {
+		return jsessionid1, fmt.Errorf("erro ao marcar mensagem como lida: %w", err)
+	}
+	if _, erros := parseMensagensSigaa(docResultado); len(erros) > 0 {
+		return jsessionid2, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+	}
+	return jsessionid2, nil
+}
+
+// EnviarMensagemDocente envia uma mensagem pela caixa postal a um docente da turma,
+// usando o link de mensagem da página de participantes da turma virtual
+func EnviarMensagemDocente(turma TurmaData, docente, assunto, mensagem string, jsessionid string, viewState string) (string, string, string, error) {
+	docParticipantes, jsessionid1, viewState1, err := abrirMenuTurma(turma, "Participantes", jsessionid, viewState)
+	if err != nil {
+		return "", jsessionid1, viewState, err
+	}
+
+	// Só a seção de docentes, e pelo nome completo: um nome parcial poderia levar a mensagem a outra pessoa
+	var encontrados []*goquery.Selection
+	docParticipantes.Find("table.participantes").Each(func(i int, table *goquery.Selection) {
+		if !strings.Contains(normalizeText(table.Closest("fieldset").Find("legend").First().Text()), "docente") {
+			return
+		}
+		table.Find("td").Each(func(j int, td *goquery.Selection) {
+			if nome := td.Find("strong").First().Text(); nome != "" && normalizeText(nome) == normalizeText(docente) {
+				encontrados = append(encontrados, td)
+			}
+		})
+	})
+	switch {
+	case len(encontrados) == 0:
+		return "", jsessionid1, viewState, fmt.Errorf("%w: docente %s não encontrado na turma %s", ErrRequisicaoInvalida, docente, turma.Nome)
+	case len(encontrados) > 1:
+		return "", jsessionid1, viewState, fmt.Errorf("%w: mais de um docente chamado %s na turma %s", ErrRequisicaoInvalida, docente, turma.Nome)
+	}
+	onclick := acaoPorRotulo(acoesLinha(encontrados[0]), "mensagem")
+	if onclick == "" {
+		return "", jsessionid1, viewState, fmt.Errorf("%w: o docente %s não aceita mensagens pela turma %s", ErrRequisicaoInvalida, docente, turma.Nome)
+	}
+
+	docForm, jsessionid2, viewState2, err := clicarLinkJsf(docParticipantes, jsfFormId(onclick), jsfParams(onclick), jsessionid1, viewState1, URL_FREQUENCIA)
+	if err != nil {
+		return "", jsessionid1, viewState, fmt.Errorf("erro ao abrir envio de mensagem: %w", err)
+	}
+
+	form := docForm.Find("form textarea").First().Closest("form")
+	if form.Length() == 0 {
+		return "", jsessionid2, viewState, fmt.Errorf("formulário de mensagem não encontrado")
+	}
+	payload := formPayload(form)
+	payload.Set("javax.faces.ViewState", viewState2)
+	payload.Set(form.Find("textarea[name]").First().AttrOr("name", ""), mensagem)
+	if name := findFieldName(form, "assunto", "titulo"); name != "" {
+		payload.Set(name, assunto)
+	}
+	if botao := findButtonName(form, "Enviar"); botao != "" {
+		payload.Set(botao, form.Find("[name='"+botao+"']").AttrOr("value", "Enviar"))
+	}
+
+	return submeterFormularioTurma(form, payload, jsessionid2, viewState)
+}
@@ -15,38 +15,110 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/login": {
-            "post": {
-                "consumes": [
-                    "application/json"
+        "/atividades-complementares": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "SIGAA"
                 ],
-                "summary": "Faz login no SIGAA",
-                "parameters": [
-                    {
-                        "description": "Credenciais do usuário",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
+                "summary": "Lista as atividades complementares e os totais por categoria frente ao exigido pelo currículo",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/main.LoginRequest"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
+                }
+            }
+        },
+        "/avaliacao-institucional": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Informa se a avaliação institucional está pendente e lista os formulários a preencher",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/biblioteca/acervo": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Público"
+                ],
+                "summary": "Busca títulos no acervo da biblioteca",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Título",
+                        "name": "titulo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Autor",
+                        "name": "autor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Assunto",
+                        "name": "assunto",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -57,8 +129,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "502": {
+                        "description": "Bad Gateway",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -69,20 +141,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/main-data": {
-            "get": {
+        "/biblioteca/emprestimos": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "SIGAA"
                 ],
-                "summary": "Retorna dados principais (nome e turmas)",
+                "summary": "Lista os empréstimos ativos na biblioteca, com prazos e multas",
+                "parameters": [
+                    {
+                        "description": "ViewState atual",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ViewStateRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -91,8 +177,17 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -103,7 +198,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/turma": {
+        "/biblioteca/renovar": {
             "post": {
                 "security": [
                     {
@@ -119,15 +214,15 @@ const docTemplate = `{
                 "tags": [
                     "SIGAA"
                 ],
-                "summary": "Retorna dados detalhados de uma turma (POST)",
+                "summary": "Renova os empréstimos informados ou, com todos=true, todos os renováveis",
                 "parameters": [
                     {
-                        "description": "Turma e viewState",
+                        "description": "ViewState e ids (códigos de barras) dos empréstimos ou todos=true",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/main.TurmaPostRequest"
+                            "$ref": "#/definitions/main.RenovarEmprestimosRequest"
                         }
                     }
                 ],
@@ -148,8 +243,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -157,8 +252,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "502": {
+                        "description": "Bad Gateway",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -168,72 +263,3061 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "main.LoginRequest": {
-            "type": "object",
-            "properties": {
-                "password": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
         },
-        "main.TurmaData": {
-            "type": "object",
-            "properties": {
-                "faltas": {
-                    "type": "integer"
-                },
-                "info": {
-                    "$ref": "#/definitions/main.TurmaInfo"
-                },
-                "nome": {
-                    "type": "string"
-                },
-                "notas": {
-                    "type": "array",
-                    "items": {
-                        "type": "number",
-                        "format": "float64"
+        "/horarios/conflitos": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Público"
+                ],
+                "summary": "Verifica choques de horário entre turmas atuais ou candidatas",
+                "parameters": [
+                    {
+                        "description": "Turmas com seus códigos de horário",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ConflitosHorarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.VerificacaoHorarios"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "main.TurmaInfo": {
-            "type": "object",
-            "properties": {
-                "componentId": {
-                    "type": "string"
-                },
-                "formName": {
-                    "type": "string"
-                },
-                "frontEndId": {
-                    "type": "string"
-                },
-                "nome": {
-                    "type": "string"
+        "/indices/historico": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cada consulta a /main-data ou a esta rota grava um snapshot quando algum índice muda.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Retorna os índices acadêmicos atuais, a evolução de cada um e o que significam",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "main.TurmaPostRequest": {
-            "type": "object",
-            "required": [
-                "turma",
-                "viewState"
-            ],
-            "properties": {
-                "turma": {
-                    "description": "Envie o objeto TurmaData completo que você obteve em /main-data.\nSe você só tiver o Info.FrontEndId, envie um TurmaData com Info preenchido.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/main.TurmaData"
+        "/indices/projecao": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "notaPadrao aplica a mesma nota às turmas do período não listadas em turmas.\nCada item de turmas exige turma e nota; o nome deve ser o da turma ou um trecho que identifique uma só.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Projeta MC, IRA e demais índices com notas hipotéticas para as turmas atuais",
+                "parameters": [
+                    {
+                        "description": "Resultados hipotéticos e, opcionalmente, cargas horárias acumuladas",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ProjecaoIndicesRequest"
                         }
-                    ]
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "avaliacaoInstitucionalPendente indica que o SIGAA cobrou a avaliação institucional, adiada para permitir o login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Faz login no SIGAA",
+                "parameters": [
+                    {
+                        "description": "Credenciais do usuário",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/main-data": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Retorna dados principais (nome e turmas)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/matricula": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Somente leitura. Fora do período de matrícula, aberta vem falso com a mensagem do SIGAA.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Retorna o estado da matrícula on-line: período e turmas já selecionadas",
+                "parameters": [
+                    {
+                        "description": "ViewState atual",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ViewStateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/matricula/comprovante": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Retorna o comprovante de solicitação de matrícula do período",
+                "parameters": [
+                    {
+                        "description": "ViewState atual",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ViewStateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/matricula/resultado": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "solicitadas aceita códigos de componente ou componente-turma (ex: 06232-01). Sem ela, usa o comprovante; fora do período de matrícula, ela é obrigatória.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Retorna o resultado do processamento de matrícula por turma e a comparação com o que foi pedido",
+                "parameters": [
+                    {
+                        "description": "ViewState e, opcionalmente, as turmas solicitadas",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ResultadoMatriculaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/matricula/submeter": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Com dryRun, as turmas são validadas pelo SIGAA mas a matrícula não é confirmada.\nA matrícula só é confirmada se todas as turmas pedidas forem adicionadas e o assistente não tiver outras selecionadas.\nQuando a matrícula não é confirmada, inclusive no dry-run, as turmas adicionadas são removidas do assistente.\nFora do dry-run, confirmacao (data de nascimento ou senha) é obrigatória.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Seleciona turmas na matrícula on-line e confirma a matrícula",
+                "parameters": [
+                    {
+                        "description": "ViewState, ids das turmas, dryRun e confirmação",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.SubmeterMatriculaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/matricula/turmas-ofertadas": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lista as turmas oferecidas para o currículo do discente na matrícula on-line",
+                "parameters": [
+                    {
+                        "description": "ViewState atual",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ViewStateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/mensagens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lista as mensagens da caixa postal do SIGAA",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/mensagens/enviar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "O docente é procurado pelo nome completo entre os docentes da turma (sem diferenciar acentos e caixa).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Envia uma mensagem pela caixa postal a um docente da turma",
+                "parameters": [
+                    {
+                        "description": "Turma, viewState, nome do docente, assunto e mensagem",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.EnviarMensagemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/mensagens/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lê uma mensagem da caixa postal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id da mensagem",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/mensagens/{id}/lida": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Marca uma mensagem da caixa postal como lida",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id da mensagem",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notas": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Baixa o HTML contendo notas",
+                "parameters": [
+                    {
+                        "description": "ViewState atual",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.NotasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/perfil": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Retorna o perfil do discente (matrícula, curso, nível, status, ingresso, e-mail e campus)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/perfil/foto": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "O novo jsessionid vem no cabeçalho X-Jsessionid.",
+                "produces": [
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Retorna a foto do perfil do discente",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/status": {
+            "get": {
+                "description": "Status: online, instavel, manutencao, fora_do_ar ou desconhecido (antes da primeira verificação).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Status"
+                ],
+                "summary": "Informa se o SIGAA está disponível e a latência recente",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.StatusSigaa"
+                        }
+                    }
+                }
+            }
+        },
+        "/trancamento": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lista turmas elegíveis para trancamento, o prazo e o andamento das solicitações",
+                "parameters": [
+                    {
+                        "description": "ViewState atual",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ViewStateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/trancamento/solicitar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exige confirmar=true: a solicitação não pode ser desfeita pela API. A senha só é usada se o SIGAA pedir.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Solicita o trancamento de uma turma",
+                "parameters": [
+                    {
+                        "description": "ViewState, id da turma, justificativa e confirmação",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.SolicitarTrancamentoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Retorna dados detalhados de uma turma (POST)",
+                "parameters": [
+                    {
+                        "description": "Turma e viewState",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.TurmaPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/enquetes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lista as enquetes abertas e encerradas da turma virtual",
+                "parameters": [
+                    {
+                        "description": "Turma e viewState",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.TurmaPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/enquetes/detalhe": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Retorna as opções de uma enquete e, se visível, o resultado",
+                "parameters": [
+                    {
+                        "description": "Turma, viewState e id da enquete",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.EnqueteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/enquetes/votar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Vota numa enquete aberta da turma virtual",
+                "parameters": [
+                    {
+                        "description": "Turma, viewState, id da enquete e valor da opção",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.EnqueteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/foruns": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lista os fóruns da turma virtual",
+                "parameters": [
+                    {
+                        "description": "Turma e viewState",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.TurmaPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/foruns/mensagens": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lista as mensagens de um tópico de fórum, com o conteúdo em Markdown",
+                "parameters": [
+                    {
+                        "description": "Turma, viewState, id do fórum e do tópico",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ForumRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/foruns/publicar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Responde a um tópico ou cria um tópico novo (sem topicoId) num fórum da turma",
+                "parameters": [
+                    {
+                        "description": "Fórum, tópico e mensagem",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.PublicarForumRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/foruns/topicos": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lista os tópicos de um fórum da turma virtual",
+                "parameters": [
+                    {
+                        "description": "Turma, viewState e id do fórum",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ForumRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/materiais": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lista arquivos, links e vídeos de cada tópico da turma virtual",
+                "parameters": [
+                    {
+                        "description": "Turma e viewState",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.TurmaPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/materiais/download": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "O novo jsessionid vem no cabeçalho X-Jsessionid; o viewState enviado continua válido.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Baixa um arquivo da turma virtual pela sessão do usuário",
+                "parameters": [
+                    {
+                        "description": "Turma, viewState e id do material",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.DownloadMaterialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/materiais/zip": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Inclui um manifest.json com o status de cada material. O limite padrão e máximo é de 200 MB.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Baixa todos os arquivos da turma virtual num ZIP organizado por tópico",
+                "parameters": [
+                    {
+                        "description": "Turma, viewState e limite em MB",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ZipMateriaisRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/noticias": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A paginação é feita pela API sobre a listagem completa; cada página consulta o SIGAA de novo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lista, paginadas, todas as notícias da turma virtual",
+                "parameters": [
+                    {
+                        "description": "Turma, viewState e paginação",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.NoticiasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/noticias/detalhe": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Retorna uma notícia da turma virtual com o conteúdo em texto rico",
+                "parameters": [
+                    {
+                        "description": "Turma, viewState e id da notícia",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.NoticiaDetalheRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/participantes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lista docentes e discentes da turma virtual",
+                "parameters": [
+                    {
+                        "description": "Turma e viewState",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.TurmaPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/questionarios": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lista os questionários da turma virtual com período e tentativas",
+                "parameters": [
+                    {
+                        "description": "Turma e viewState",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.TurmaPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/questionarios/questoes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Retorna as questões de um questionário aberto",
+                "parameters": [
+                    {
+                        "description": "Turma, viewState e id do questionário",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.QuestionarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/questionarios/responder": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Envia as respostas de um questionário (id da questão -\u003e valores escolhidos)",
+                "parameters": [
+                    {
+                        "description": "Questionário e respostas",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponderQuestionarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/questionarios/resultado": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Retorna nota e feedback já liberados de um questionário",
+                "parameters": [
+                    {
+                        "description": "Turma, viewState e id do questionário",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.QuestionarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/tarefas": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Lista as tarefas da turma virtual com prazos, situação do envio e nota",
+                "parameters": [
+                    {
+                        "description": "Turma e viewState",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.TurmaPostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turma/tarefas/enviar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SIGAA"
+                ],
+                "summary": "Envia o arquivo de uma tarefa da turma virtual",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "TurmaData em JSON",
+                        "name": "turma",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ViewState atual",
+                        "name": "viewState",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id da tarefa",
+                        "name": "tarefaId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comentário do envio",
+                        "name": "comentario",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Arquivo da tarefa",
+                        "name": "arquivo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/turmas-abertas": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Público"
+                ],
+                "summary": "Busca turmas abertas na consulta pública do SIGAA",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ano",
+                        "name": "ano",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Período",
+                        "name": "periodo",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nível de ensino (padrão G)",
+                        "name": "nivel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Código do componente",
+                        "name": "componente",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id ou nome do departamento",
+                        "name": "departamento",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome do docente",
+                        "name": "docente",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "main.CombinacaoTurmas": {
+            "type": "object",
+            "properties": {
+                "cargaHorariaSemanal": {
+                    "type": "integer"
+                },
+                "turmas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.TurmaCandidata"
+                    }
+                }
+            }
+        },
+        "main.ConflitoHorario": {
+            "type": "object",
+            "properties": {
+                "slot": {
+                    "$ref": "#/definitions/main.HorarioSlot"
+                },
+                "turmas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "main.ConflitosHorarioRequest": {
+            "type": "object",
+            "required": [
+                "turmas"
+            ],
+            "properties": {
+                "turmas": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/main.TurmaCandidata"
+                    }
+                }
+            }
+        },
+        "main.CronogramaItem": {
+            "type": "object",
+            "properties": {
+                "conteudo": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.DisciplinaNotas": {
+            "type": "object",
+            "properties": {
+                "codigo": {
+                    "type": "string"
+                },
+                "faltas": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "notas": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "resultado": {
+                    "type": "string"
+                },
+                "situacao": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.DownloadMaterialRequest": {
+            "type": "object",
+            "required": [
+                "materialId",
+                "turma",
+                "viewState"
+            ],
+            "properties": {
+                "materialId": {
+                    "type": "string"
+                },
+                "turma": {
+                    "$ref": "#/definitions/main.TurmaData"
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.EnqueteRequest": {
+            "type": "object",
+            "required": [
+                "enqueteId",
+                "turma",
+                "viewState"
+            ],
+            "properties": {
+                "enqueteId": {
+                    "type": "string"
+                },
+                "opcao": {
+                    "type": "string"
+                },
+                "turma": {
+                    "$ref": "#/definitions/main.TurmaData"
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.EnviarMensagemRequest": {
+            "type": "object",
+            "required": [
+                "assunto",
+                "docente",
+                "mensagem",
+                "turma",
+                "viewState"
+            ],
+            "properties": {
+                "assunto": {
+                    "type": "string"
+                },
+                "docente": {
+                    "type": "string"
+                },
+                "mensagem": {
+                    "type": "string"
+                },
+                "turma": {
+                    "$ref": "#/definitions/main.TurmaData"
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.ForumRequest": {
+            "type": "object",
+            "required": [
+                "forumId",
+                "turma",
+                "viewState"
+            ],
+            "properties": {
+                "forumId": {
+                    "type": "string"
+                },
+                "topicoId": {
+                    "type": "string"
+                },
+                "turma": {
+                    "$ref": "#/definitions/main.TurmaData"
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.HorarioSlot": {
+            "type": "object",
+            "properties": {
+                "aula": {
+                    "type": "integer"
+                },
+                "dia": {
+                    "type": "integer"
+                },
+                "diaNome": {
+                    "type": "string"
+                },
+                "fim": {
+                    "type": "string"
+                },
+                "inicio": {
+                    "type": "string"
+                },
+                "turno": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.NotasRequest": {
+            "type": "object",
+            "required": [
+                "viewState"
+            ],
+            "properties": {
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.Noticia": {
+            "type": "object",
+            "properties": {
+                "conteudo": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "titulo": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.NoticiaDetalheRequest": {
+            "type": "object",
+            "required": [
+                "noticiaId",
+                "turma",
+                "viewState"
+            ],
+            "properties": {
+                "noticiaId": {
+                    "type": "string"
+                },
+                "turma": {
+                    "$ref": "#/definitions/main.TurmaData"
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.NoticiasRequest": {
+            "type": "object",
+            "required": [
+                "turma",
+                "viewState"
+            ],
+            "properties": {
+                "pagina": {
+                    "type": "integer"
+                },
+                "porPagina": {
+                    "type": "integer"
+                },
+                "turma": {
+                    "$ref": "#/definitions/main.TurmaData"
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.ProjecaoIndicesRequest": {
+            "type": "object",
+            "properties": {
+                "chAprovada": {
+                    "type": "integer"
+                },
+                "chUtilizada": {
+                    "type": "integer"
+                },
+                "notaPadrao": {
+                    "type": "number"
+                },
+                "periodosCursados": {
+                    "type": "integer"
+                },
+                "turmas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ResultadoHipotetico"
+                    }
+                }
+            }
+        },
+        "main.PublicarForumRequest": {
+            "type": "object",
+            "required": [
+                "forumId",
+                "mensagem",
+                "turma",
+                "viewState"
+            ],
+            "properties": {
+                "forumId": {
+                    "type": "string"
+                },
+                "mensagem": {
+                    "type": "string"
+                },
+                "titulo": {
+                    "type": "string"
+                },
+                "topicoId": {
+                    "type": "string"
+                },
+                "turma": {
+                    "$ref": "#/definitions/main.TurmaData"
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.QuestionarioRequest": {
+            "type": "object",
+            "required": [
+                "questionarioId",
+                "turma",
+                "viewState"
+            ],
+            "properties": {
+                "questionarioId": {
+                    "type": "string"
+                },
+                "turma": {
+                    "$ref": "#/definitions/main.TurmaData"
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.RenovarEmprestimosRequest": {
+            "type": "object",
+            "required": [
+                "viewState"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "todos": {
+                    "description": "Renova todos os renováveis; exige ids vazio",
+                    "type": "boolean"
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.ResponderQuestionarioRequest": {
+            "type": "object",
+            "required": [
+                "questionarioId",
+                "respostas",
+                "turma",
+                "viewState"
+            ],
+            "properties": {
+                "questionarioId": {
+                    "type": "string"
+                },
+                "respostas": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "turma": {
+                    "$ref": "#/definitions/main.TurmaData"
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.ResultadoHipotetico": {
+            "type": "object",
+            "required": [
+                "nota",
+                "turma"
+            ],
+            "properties": {
+                "cargaHoraria": {
+                    "type": "integer"
+                },
+                "nota": {
+                    "type": "number"
+                },
+                "situacao": {
+                    "type": "string"
+                },
+                "turma": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.ResultadoMatriculaRequest": {
+            "type": "object",
+            "required": [
+                "viewState"
+            ],
+            "properties": {
+                "solicitadas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.SolicitarTrancamentoRequest": {
+            "type": "object",
+            "required": [
+                "justificativa",
+                "turmaId",
+                "viewState"
+            ],
+            "properties": {
+                "confirmar": {
+                    "type": "boolean"
+                },
+                "justificativa": {
+                    "type": "string"
+                },
+                "senha": {
+                    "type": "string"
+                },
+                "turmaId": {
+                    "type": "string"
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.StatusSigaa": {
+            "type": "object",
+            "properties": {
+                "amostras": {
+                    "type": "integer"
+                },
+                "circuitoAberto": {
+                    "description": "Com o circuito aberto, a API recusa chamadas ao SIGAA até ProximaTentativa",
+                    "type": "boolean"
+                },
+                "disponibilidade": {
+                    "description": "Fração das requisições recentes que tiveram resposta",
+                    "type": "number"
+                },
+                "latenciaMediaMs": {
+                    "type": "integer"
+                },
+                "latenciaUltimaMs": {
+                    "type": "integer"
+                },
+                "manutencaoDesde": {
+                    "type": "string"
+                },
+                "mensagem": {
+                    "type": "string"
+                },
+                "proximaTentativa": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "ultimaVerificacao": {
+                    "type": "string"
+                },
+                "ultimoSucesso": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.SubmeterMatriculaRequest": {
+            "type": "object",
+            "required": [
+                "turmas",
+                "viewState"
+            ],
+            "properties": {
+                "confirmacao": {
+                    "type": "string"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "turmas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.TurmaCandidata": {
+            "type": "object",
+            "required": [
+                "componente",
+                "horarios"
+            ],
+            "properties": {
+                "componente": {
+                    "type": "string"
+                },
+                "horarios": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "turma": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.TurmaData": {
+            "type": "object",
+            "properties": {
+                "cronograma": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.CronogramaItem"
+                    }
+                },
+                "faltas": {
+                    "type": "integer"
+                },
+                "horarios": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "info": {
+                    "$ref": "#/definitions/main.TurmaInfo"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "notas": {
+                    "$ref": "#/definitions/main.DisciplinaNotas"
+                },
+                "noticia": {
+                    "$ref": "#/definitions/main.Noticia"
+                }
+            }
+        },
+        "main.TurmaInfo": {
+            "type": "object",
+            "properties": {
+                "componentId": {
+                    "type": "string"
+                },
+                "formName": {
+                    "type": "string"
+                },
+                "frontEndId": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.TurmaPostRequest": {
+            "type": "object",
+            "required": [
+                "turma",
+                "viewState"
+            ],
+            "properties": {
+                "turma": {
+                    "$ref": "#/definitions/main.TurmaData"
+                },
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.VerificacaoHorarios": {
+            "type": "object",
+            "properties": {
+                "cargaHorariaSemanal": {
+                    "description": "Só vem quando há uma turma por componente; com alternativas, cada combinação traz a sua",
+                    "type": "integer"
+                },
+                "combinacoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.CombinacaoTurmas"
+                    }
+                },
+                "conflitos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ConflitoHorario"
+                    }
+                },
+                "truncado": {
+                    "description": "Havia mais combinações do que MAX_COMBINACOES",
+                    "type": "boolean"
+                }
+            }
+        },
+        "main.ViewStateRequest": {
+            "type": "object",
+            "required": [
+                "viewState"
+            ],
+            "properties": {
+                "viewState": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.ZipMateriaisRequest": {
+            "type": "object",
+            "required": [
+                "turma",
+                "viewState"
+            ],
+            "properties": {
+                "limiteMb": {
+                    "type": "integer"
+                },
+                "turma": {
+                    "$ref": "#/definitions/main.TurmaData"
                 },
                 "viewState": {
                     "type": "string"
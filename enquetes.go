@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func parseEnquetes(doc *goquery.Document) []Enquete {
+	enquetes := []Enquete{}
+
+	table := doc.Find("table.listing, table.listagem").First()
+	headers := tableHeaders(table)
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() < len(headers) || cells.Length() < 2 {
+			return
+		}
+
+		enquete := Enquete{
+			Pergunta: cellByHeader(headers, cells, "pergunta", "enquete", "titulo"),
+			acoes:    acoesLinha(row),
+		}
+		if enquete.Pergunta == "" {
+			enquete.Pergunta = strings.TrimSpace(cells.First().Text())
+		}
+
+		datas := reDataHora.FindAllString(row.Text(), -1)
+		if len(datas) > 0 {
+			enquete.Inicio = datas[0]
+		}
+		if len(datas) > 1 {
+			enquete.Fim = datas[len(datas)-1]
+		}
+
+		enquete.Aberta = acaoPorRotulo(enquete.acoes, "votar") != ""
+		enquete.ResultadoVisivel = acaoPorRotulo(enquete.acoes, "resultado") != ""
+
+		enquete.Id = idAcoes(enquete.acoes)
+		if enquete.Id == "" {
+			enquete.Id = fmt.Sprintf("%d", i)
+		}
+
+		enquetes = append(enquetes, enquete)
+	})
+
+	return enquetes
+}
+
+// abrirEnquete abre a listagem de enquetes da turma e localiza a enquete pedida
+func abrirEnquete(turma TurmaData, enqueteId, jsessionid, viewState string) (*goquery.Document, Enquete, string, string, error) {
+	var enquete Enquete
+	docLista, jsessionid1, viewState1, err := abrirMenuTurma(turma, "Enquetes", jsessionid, viewState)
+	if err != nil {
+		return nil, enquete, jsessionid1, viewState, err
+	}
+	for _, e := range parseEnquetes(docLista) {
+		if e.Id == enqueteId {
+			return docLista, e, jsessionid1, viewState1, nil
+		}
+	}
+	return nil, enquete, jsessionid1, viewState, fmt.Errorf("enquete %s não encontrada na turma %s", enqueteId, turma.Nome)
+}
+
+func GetEnquetes(turma TurmaData, jsessionid string, viewState string) ([]Enquete, string, string, error) {
+	doc, newJsessionid, newViewState, err := visitarMenuTurma(turma, "Enquetes", jsessionid, viewState)
+	if err != nil {
+		return nil, newJsessionid, newViewState, err
+	}
+	return parseEnquetes(doc), newJsessionid, newViewState, nil
+}
+
+// GetDetalheEnquete traz o resultado quando o docente o deixou visível; senão, as opções do formulário de voto
+func GetDetalheEnquete(turma TurmaData, enqueteId string, jsessionid string, viewState string) (DetalheEnquete, string, string, error) {
+	detalhe := DetalheEnquete{Opcoes: []OpcaoEnquete{}}
+	docLista, enquete, jsessionid1, viewState1, err := abrirEnquete(turma, enqueteId, jsessionid, viewState)
+	if err != nil {
+		return detalhe, jsessionid1, viewState, err
+	}
+	detalhe.Enquete = enquete
+
+	onclick := acaoPorRotulo(enquete.acoes, "resultado")
+	if onclick == "" {
+		onclick = acaoPorRotulo(enquete.acoes, "votar")
+	}
+	if onclick == "" {
+		newJsessionid, newViewState, err := voltarAoPortal(jsessionid1, viewState)
+		return detalhe, newJsessionid, newViewState, err
+	}
+
+	doc, jsessionid2, _, err := clicarLinkJsf(docLista, jsfFormId(onclick), jsfParams(onclick), jsessionid1, viewState1, URL_FREQUENCIA)
+	if err != nil {
+		return detalhe, jsessionid1, viewState, fmt.Errorf("erro ao abrir enquete %s: %w", enquete.Pergunta, err)
+	}
+	if enquete.ResultadoVisivel {
+		detalhe.Opcoes = parseResultadoEnquete(doc)
+	} else {
+		detalhe.Opcoes = parseOpcoesEnquete(doc)
+	}
+
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid2, viewState)
+	return detalhe, newJsessionid, newViewState, err
+}
+
+func parseOpcoesEnquete(doc *goquery.Document) []OpcaoEnquete {
+	opcoes := []OpcaoEnquete{}
+	doc.Find("input[type='radio']").Each(func(i int, radio *goquery.Selection) {
+		opcoes = append(opcoes, OpcaoEnquete{
+			Valor: radio.AttrOr("value", ""),
+			Texto: rotuloCampo(radio.Closest("form"), radio),
+		})
+	})
+	return opcoes
+}
+
+// parseResultadoEnquete lê a tabela de resultado: opção, votos e percentual
+func parseResultadoEnquete(doc *goquery.Document) []OpcaoEnquete {
+	opcoes := []OpcaoEnquete{}
+
+	table := doc.Find("table.listing, table.listagem, table.visualizacao").First()
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() < 2 {
+			return
+		}
+		opcao := OpcaoEnquete{Texto: strings.TrimSpace(cells.First().Text())}
+		cells.Slice(1, cells.Length()).Each(func(j int, cell *goquery.Selection) {
+			texto := strings.TrimSpace(cell.Text())
+			if strings.Contains(texto, "%") {
+				opcao.Percentual = texto
+			} else if texto != "" {
+				opcao.Votos = parseInt(texto)
+			}
+		})
+		opcoes = append(opcoes, opcao)
+	})
+
+	return opcoes
+}
+
+func VotarEnquete(turma TurmaData, enqueteId, opcao string, jsessionid string, viewState string) (string, string, string, error) {
+	docLista, enquete, jsessionid1, viewState1, err := abrirEnquete(turma, enqueteId, jsessionid, viewState)
+	if err != nil {
+		return "", jsessionid1, viewState, err
+	}
+	onclick := acaoPorRotulo(enquete.acoes, "votar")
+	if onclick == "" {
+		return "", jsessionid1, viewState, fmt.Errorf("%w: a enquete %s não está aberta para votação", ErrRequisicaoInvalida, enquete.Pergunta)
+	}
+
+	doc, jsessionid2, viewState2, err := clicarLinkJsf(docLista, jsfFormId(onclick), jsfParams(onclick), jsessionid1, viewState1, URL_FREQUENCIA)
+	if err != nil {
+		return "", jsessionid1, viewState, fmt.Errorf("erro ao abrir votação da enquete %s: %w", enquete.Pergunta, err)
+	}
+
+	radio := doc.Find("input[type='radio']").FilterFunction(func(i int, r *goquery.Selection) bool {
+		return r.AttrOr("value", "") == opcao
+	}).First()
+	if radio.Length() == 0 {
+		return "", jsessionid2, viewState, fmt.Errorf("%w: opção %s inválida para a enquete", ErrRequisicaoInvalida, opcao)
+	}
+
+	form := radio.Closest("form")
+	payload := formPayload(form)
+	payload.Set("javax.faces.ViewState", viewState2)
+	payload.Set(radio.AttrOr("name", ""), opcao)
+	if botao := findButtonName(form, "Votar"); botao != "" {
+		payload.Set(botao, form.Find("[name='"+botao+"']").AttrOr("value", "Votar"))
+	}
+
+	return submeterFormularioTurma(form, payload, jsessionid2, viewState)
+}
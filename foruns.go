@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func parseForuns(doc *goquery.Document) []Forum {
+	foruns := []Forum{}
+
+	table := doc.Find("table.listing, table.listagem").First()
+	headers := tableHeaders(table)
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		onclick, textoLink := linkAbrirLinha(row)
+		if onclick == "" || cells.Length() < len(headers) {
+			return
+		}
+
+		forum := Forum{
+			Titulo:         cellByHeader(headers, cells, "forum", "titulo"),
+			Autor:          cellByHeader(headers, cells, "autor", "criado"),
+			Topicos:        parseInt(cellByHeader(headers, cells, "topicos")),
+			UltimaMensagem: cellByHeader(headers, cells, "ultima"),
+			formAbrir:      jsfFormId(onclick),
+			paramsAbrir:    jsfParams(onclick),
+		}
+		if forum.Titulo == "" {
+			forum.Titulo = textoLink
+		}
+		forum.Id = forum.paramsAbrir.Get("id")
+		if forum.Id == "" {
+			forum.Id = fmt.Sprintf("%d", i)
+		}
+		foruns = append(foruns, forum)
+	})
+
+	return foruns
+}
+
+func parseTopicosForum(doc *goquery.Document) []TopicoForum {
+	topicos := []TopicoForum{}
+
+	table := doc.Find("table.listing, table.listagem").First()
+	headers := tableHeaders(table)
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		onclick, textoLink := linkAbrirLinha(row)
+		if onclick == "" || cells.Length() < len(headers) {
+			return
+		}
+
+		topico := TopicoForum{
+			Titulo:         cellByHeader(headers, cells, "topico", "titulo", "assunto"),
+			Autor:          cellByHeader(headers, cells, "autor"),
+			Respostas:      parseInt(cellByHeader(headers, cells, "respostas", "mensagens")),
+			UltimaMensagem: cellByHeader(headers, cells, "ultima"),
+			formAbrir:      jsfFormId(onclick),
+			paramsAbrir:    jsfParams(onclick),
+		}
+		if topico.Titulo == "" {
+			topico.Titulo = textoLink
+		}
+		topico.Id = topico.paramsAbrir.Get("id")
+		if topico.Id == "" {
+			topico.Id = fmt.Sprintf("%d", i)
+		}
+		topicos = append(topicos, topico)
+	})
+
+	return topicos
+}
+
+// parseMensagensForum lê as mensagens de um tópico: autor e data na primeira coluna, texto na segunda
+func parseMensagensForum(doc *goquery.Document) []MensagemForum {
+	mensagens := []MensagemForum{}
+
+	doc.Find("table.listing tbody tr, table.listagem tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Children().Filter("td")
+		if cells.Length() < 2 {
+			return
+		}
+
+		linhasAutor := textLines(cells.First())
+		if len(linhasAutor) == 0 {
+			return
+		}
+		mensagem := MensagemForum{
+			Autor:    linhasAutor[0],
+			Data:     reDataHora.FindString(strings.Join(linhasAutor, " ")),
+			Conteudo: htmlToMarkdown(cells.Eq(1)),
+		}
+		if mensagem.Data == "" {
+			mensagem.Data = reDataHora.FindString(row.Text())
+		}
+		mensagens = append(mensagens, mensagem)
+	})
+
+	return mensagens
+}
+
+// abrirForum entra na turma, abre o menu de fóruns e, se informado, o fórum pedido.
+// Devolve a página alcançada e a sessão ainda dentro da turma virtual.
+func abrirForum(turma TurmaData, forumId, jsessionid, viewState string) (*goquery.Document, string, string, error) {
+	docTurma, jsessionid1, err := acessarTurmaVirtual(turma, jsessionid, viewState)
+	if err != nil {
+		return nil, jsessionid, viewState, err
+	}
+	viewState1, err := parseViewState(docTurma, "turma_"+turma.Nome)
+	if err != nil {
+		return nil, jsessionid1, viewState, err
+	}
+
+	docForuns, jsessionid2, viewState2, err := navegarMenuTurma(docTurma, "Fóruns", jsessionid1, viewState1)
+	if err != nil || forumId == "" {
+		return docForuns, jsessionid2, viewState2, err
+	}
+
+	for _, forum := range parseForuns(docForuns) {
+		if forum.Id == forumId {
+			docForum, jsessionid3, viewState3, err := clicarLinkJsf(docForuns, forum.formAbrir, forum.paramsAbrir, jsessionid2, viewState2, URL_FREQUENCIA)
+			if err != nil {
+				return nil, jsessionid2, viewState2, fmt.Errorf("erro ao abrir fórum %s: %w", forum.Titulo, err)
+			}
+			return docForum, jsessionid3, viewState3, nil
+		}
+	}
+	return nil, jsessionid2, viewState2, fmt.Errorf("fórum %s não encontrado na turma %s", forumId, turma.Nome)
+}
+
+func abrirTopicoForum(docForum *goquery.Document, topicoId, jsessionid, viewState string) (*goquery.Document, string, string, error) {
+	for _, topico := range parseTopicosForum(docForum) {
+		if topico.Id == topicoId {
+			docTopico, newJsessionid, newViewState, err := clicarLinkJsf(docForum, topico.formAbrir, topico.paramsAbrir, jsessionid, viewState, URL_FREQUENCIA)
+			if err != nil {
+				return nil, jsessionid, viewState, fmt.Errorf("erro ao abrir tópico %s: %w", topico.Titulo, err)
+			}
+			return docTopico, newJsessionid, newViewState, nil
+		}
+	}
+	return nil, jsessionid, viewState, fmt.Errorf("tópico %s não encontrado no fórum", topicoId)
+}
+
+func GetForuns(turma TurmaData, jsessionid string, viewState string) ([]Forum, string, string, error) {
+	doc, jsessionid1, _, err := abrirForum(turma, "", jsessionid, viewState)
+	if err != nil {
+		return nil, jsessionid1, viewState, err
+	}
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid1, viewState)
+	return parseForuns(doc), newJsessionid, newViewState, err
+}
+
+func GetTopicosForum(turma TurmaData, forumId string, jsessionid string, viewState string) ([]TopicoForum, string, string, error) {
+	doc, jsessionid1, _, err := abrirForum(turma, forumId, jsessionid, viewState)
+	if err != nil {
+		return nil, jsessionid1, viewState, err
+	}
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid1, viewState)
+	return parseTopicosForum(doc), newJsessionid, newViewState, err
+}
+
+func GetMensagensForum(turma TurmaData, forumId, topicoId string, jsessionid string, viewState string) ([]MensagemForum, string, string, error) {
+	docForum, jsessionid1, viewState1, err := abrirForum(turma, forumId, jsessionid, viewState)
+	if err != nil {
+		return nil, jsessionid1, viewState, err
+	}
+	docTopico, jsessionid2, _, err := abrirTopicoForum(docForum, topicoId, jsessionid1, viewState1)
+	if err != nil {
+		return nil, jsessionid2, viewState, err
+	}
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid2, viewState)
+	return parseMensagensForum(docTopico), newJsessionid, newViewState, err
+}
+
+// PublicarForum responde a um tópico ou, sem topicoId, cria um tópico novo com o título informado
+func PublicarForum(turma TurmaData, forumId, topicoId, titulo, mensagem string, jsessionid string, viewState string) (string, string, string, error) {
+	docForum, jsessionid1, viewState1, err := abrirForum(turma, forumId, jsessionid, viewState)
+	if err != nil {
+		return "", jsessionid1, viewState, err
+	}
+
+	var docForm *goquery.Document
+	var jsessionid2, viewState2 string
+	if topicoId != "" {
+		docTopico, jsessionidTopico, viewStateTopico, err := abrirTopicoForum(docForum, topicoId, jsessionid1, viewState1)
+		if err != nil {
+			return "", jsessionidTopico, viewState, err
+		}
+		docForm, jsessionid2, viewState2 = docTopico, jsessionidTopico, viewStateTopico
+		// Alguns tópicos já trazem o formulário de resposta; outros pedem o clique em "Responder"
+		if docTopico.Find("form textarea").Length() == 0 {
+			docForm, jsessionid2, viewState2, err = clicarLinkPorTexto(docTopico, "Responder", jsessionidTopico, viewStateTopico, URL_FREQUENCIA)
+			if err != nil {
+				return "", jsessionidTopico, viewState, err
+			}
+		}
+	} else {
+		docForm, jsessionid2, viewState2, err = clicarLinkPorTexto(docForum, "Novo Tópico", jsessionid1, viewState1, URL_FREQUENCIA)
+		if err != nil {
+			return "", jsessionid1, viewState, err
+		}
+	}
+
+	form := docForm.Find("form textarea").First().Closest("form")
+	if form.Length() == 0 {
+		return "", jsessionid2, viewState, fmt.Errorf("formulário de mensagem do fórum não encontrado")
+	}
+	payload := formPayload(form)
+	payload.Set("javax.faces.ViewState", viewState2)
+	payload.Set(form.Find("textarea[name]").First().AttrOr("name", ""), textoParaHtml(mensagem))
+	if titulo != "" {
+		if name := findFieldName(form, "titulo", "assunto"); name != "" {
+			payload.Set(name, titulo)
+		}
+	}
+	for _, rotulo := range []string{"Cadastrar", "Responder", "Enviar", "Publicar"} {
+		if botao := findButtonName(form, rotulo); botao != "" {
+			payload.Set(botao, rotulo)
+			break
+		}
+	}
+
+	return submeterFormularioTurma(form, payload, jsessionid2, viewState)
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Limite de combinações devolvidas, para não explodir com muitas turmas por componente
+const MAX_COMBINACOES = 50
+
+var diasSemana = map[int]string{
+	2: "Segunda",
+	3: "Terça",
+	4: "Quarta",
+	5: "Quinta",
+	6: "Sexta",
+	7: "Sábado",
+}
+
+// Horário de início de cada aula por turno, segundo a grade da UFRPE (aulas de 1 hora)
+var inicioAulas = map[string][]string{
+	"M": {"07:00", "08:00", "09:00", "10:00", "11:00", "12:00"},
+	"T": {"13:00", "14:00", "15:00", "16:00", "17:00", "18:00"},
+	"N": {"19:00", "20:00", "21:00", "22:00"},
+}
+
+var reCodigoHorario = regexp.MustCompile(`^([2-7]+)([MTN])([1-7]+)$`)
+
+// decodeHorario expande um código como "24M12" nas aulas que ele representa
+func decodeHorario(codigo string) ([]HorarioSlot, error) {
+	matches := reCodigoHorario.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(codigo)))
+	if len(matches) < 4 {
+		return nil, fmt.Errorf("código de horário inválido: %s", codigo)
+	}
+	turno := matches[2]
+	inicios := inicioAulas[turno]
+
+	var slots []HorarioSlot
+	for _, d := range matches[1] {
+		dia := int(d - '0')
+		for _, a := range matches[3] {
+			aula := int(a - '0')
+			if aula > len(inicios) {
+				return nil, fmt.Errorf("aula %d não existe no turno %s: %s", aula, turno, codigo)
+			}
+			slots = append(slots, HorarioSlot{
+				Dia:     dia,
+				DiaNome: diasSemana[dia],
+				Turno:   turno,
+				Aula:    aula,
+				Inicio:  inicios[aula-1],
+				Fim:     fimAula(inicios[aula-1]),
+			})
+		}
+	}
+	return slots, nil
+}
+
+func fimAula(inicio string) string {
+	var h, m int
+	fmt.Sscanf(inicio, "%d:%d", &h, &m)
+	return fmt.Sprintf("%02d:%02d", h+1, m)
+}
+
+func slotKey(slot HorarioSlot) string {
+	return fmt.Sprintf("%d%s%d", slot.Dia, slot.Turno, slot.Aula)
+}
+
+func nomeTurmaCandidata(turma TurmaCandidata) string {
+	if turma.Turma == "" {
+		return turma.Componente
+	}
+	return turma.Componente + " - " + turma.Turma
+}
+
+func decodeTurmaCandidata(turma TurmaCandidata) ([]HorarioSlot, error) {
+	var slots []HorarioSlot
+	vistos := map[string]bool{}
+	for _, codigo := range turma.Horarios {
+		decoded, err := decodeHorario(codigo)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", nomeTurmaCandidata(turma), err)
+		}
+		for _, slot := range decoded {
+			if !vistos[slotKey(slot)] {
+				vistos[slotKey(slot)] = true
+				slots = append(slots, slot)
+			}
+		}
+	}
+	return slots, nil
+}
+
+// VerificarHorarios aponta as aulas em que turmas de componentes diferentes se sobrepõem e as combinações
+// sem conflito escolhendo uma turma por componente. Turmas do mesmo componente são alternativas entre si,
+// então não conflitam umas com as outras e a carga horária é calculada por combinação.
+func VerificarHorarios(turmas []TurmaCandidata) (VerificacaoHorarios, error) {
+	slotsPorTurma := make([][]HorarioSlot, len(turmas))
+	for i, turma := range turmas {
+		slots, err := decodeTurmaCandidata(turma)
+		if err != nil {
+			return VerificacaoHorarios{}, err
+		}
+		slotsPorTurma[i] = slots
+	}
+
+	ocupacao := map[string][]int{}
+	slotPorChave := map[string]HorarioSlot{}
+	for i, slots := range slotsPorTurma {
+		for _, slot := range slots {
+			key := slotKey(slot)
+			slotPorChave[key] = slot
+			ocupacao[key] = append(ocupacao[key], i)
+		}
+	}
+
+	conflitos := []ConflitoHorario{}
+	for key, indices := range ocupacao {
+		componentes := map[string]bool{}
+		nomes := []string{}
+		for _, i := range indices {
+			componentes[turmas[i].Componente] = true
+			nomes = append(nomes, nomeTurmaCandidata(turmas[i]))
+		}
+		if len(componentes) > 1 {
+			conflitos = append(conflitos, ConflitoHorario{Slot: slotPorChave[key], Turmas: nomes})
+		}
+	}
+	sort.Slice(conflitos, func(i, j int) bool {
+		a, b := conflitos[i].Slot, conflitos[j].Slot
+		if a.Dia != b.Dia {
+			return a.Dia < b.Dia
+		}
+		return a.Inicio < b.Inicio
+	})
+
+	verificacao := VerificacaoHorarios{Conflitos: conflitos}
+	verificacao.Combinacoes, verificacao.Truncado = gerarCombinacoes(turmas, slotsPorTurma)
+
+	// Sem alternativas, o conjunto informado é a própria grade e a carga horária não depende de escolha
+	componentes := map[string]bool{}
+	for _, turma := range turmas {
+		componentes[turma.Componente] = true
+	}
+	if len(componentes) == len(turmas) {
+		cargaHoraria := len(ocupacao)
+		verificacao.CargaHorariaSemanal = &cargaHoraria
+	}
+	return verificacao, nil
+}
+
+// gerarCombinacoes escolhe uma turma de cada componente, descartando escolhas com choque de horário.
+// Devolve true quando parou em MAX_COMBINACOES e havia mais combinações possíveis.
+func gerarCombinacoes(turmas []TurmaCandidata, slotsPorTurma [][]HorarioSlot) ([]CombinacaoTurmas, bool) {
+	var componentes []string
+	opcoes := map[string][]int{}
+	for i, turma := range turmas {
+		if _, exists := opcoes[turma.Componente]; !exists {
+			componentes = append(componentes, turma.Componente)
+		}
+		opcoes[turma.Componente] = append(opcoes[turma.Componente], i)
+	}
+
+	combinacoes := []CombinacaoTurmas{}
+	escolhidas := []int{}
+	ocupados := map[string]bool{}
+	truncado := false
+
+	var backtrack func(nivel int)
+	backtrack = func(nivel int) {
+		if truncado {
+			return
+		}
+		if nivel == len(componentes) {
+			if len(combinacoes) >= MAX_COMBINACOES {
+				truncado = true
+				return
+			}
+			combinacao := CombinacaoTurmas{CargaHorariaSemanal: len(ocupados)}
+			for _, i := range escolhidas {
+				combinacao.Turmas = append(combinacao.Turmas, turmas[i])
+			}
+			combinacoes = append(combinacoes, combinacao)
+			return
+		}
+
+		for _, i := range opcoes[componentes[nivel]] {
+			livre := true
+			for _, slot := range slotsPorTurma[i] {
+				if ocupados[slotKey(slot)] {
+					livre = false
+					break
+				}
+			}
+			if !livre {
+				continue
+			}
+
+			for _, slot := range slotsPorTurma[i] {
+				ocupados[slotKey(slot)] = true
+			}
+			escolhidas = append(escolhidas, i)
+			backtrack(nivel + 1)
+			escolhidas = escolhidas[:len(escolhidas)-1]
+			for _, slot := range slotsPorTurma[i] {
+				delete(ocupados, slotKey(slot))
+			}
+		}
+	}
+	backtrack(0)
+
+	return combinacoes, truncado
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDecodeHorario(t *testing.T) {
+	tests := []struct {
+		codigo   string
+		esperado []string // dia, turno e aula de cada slot, na ordem
+		inicio   string   // início do primeiro slot
+		fim      string   // fim do primeiro slot
+		erro     bool
+	}{
+		{codigo: "24M12", esperado: []string{"2M1", "2M2", "4M1", "4M2"}, inicio: "07:00", fim: "08:00"},
+		{codigo: "6T3", esperado: []string{"6T3"}, inicio: "15:00", fim: "16:00"},
+		{codigo: " 35n4 ", esperado: []string{"3N4", "5N4"}, inicio: "22:00", fim: "23:00"},
+		{codigo: "7M56", esperado: []string{"7M5", "7M6"}, inicio: "11:00", fim: "12:00"},
+		{codigo: "2N5", erro: true},
+		{codigo: "1M1", erro: true},
+		{codigo: "2X1", erro: true},
+		{codigo: "", erro: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.codigo, func(t *testing.T) {
+			slots, err := decodeHorario(tt.codigo)
+			if tt.erro {
+				if err == nil {
+					t.Fatalf("decodeHorario(%q) = %v, esperava erro", tt.codigo, slots)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeHorario(%q): %v", tt.codigo, err)
+			}
+			var chaves []string
+			for _, slot := range slots {
+				chaves = append(chaves, slotKey(slot))
+			}
+			if !slices.Equal(chaves, tt.esperado) {
+				t.Errorf("decodeHorario(%q) = %v, esperava %v", tt.codigo, chaves, tt.esperado)
+			}
+			if slots[0].Inicio != tt.inicio || slots[0].Fim != tt.fim {
+				t.Errorf("decodeHorario(%q) começa %s-%s, esperava %s-%s", tt.codigo, slots[0].Inicio, slots[0].Fim, tt.inicio, tt.fim)
+			}
+		})
+	}
+}
+
+func TestVerificarHorarios(t *testing.T) {
+	tests := []struct {
+		nome         string
+		turmas       []TurmaCandidata
+		conflitos    [][]string // turmas de cada conflito, em ordem de dia e horário
+		combinacoes  []int      // carga horária semanal de cada combinação
+		cargaHoraria *int
+		erro         bool
+	}{
+		{
+			nome: "sem conflito",
+			turmas: []TurmaCandidata{
+				{Componente: "Cálculo", Horarios: []string{"24M12"}},
+				{Componente: "Física", Horarios: []string{"35M12"}},
+			},
+			combinacoes:  []int{8},
+			cargaHoraria: ptr(8),
+		},
+		{
+			nome: "choque entre componentes",
+			turmas: []TurmaCandidata{
+				{Componente: "Cálculo", Horarios: []string{"24M12"}},
+				{Componente: "Física", Horarios: []string{"2M2", "6M1"}},
+			},
+			conflitos:    [][]string{{"Cálculo", "Física"}},
+			combinacoes:  []int{},
+			cargaHoraria: ptr(5),
+		},
+		{
+			nome: "turmas do mesmo componente são alternativas",
+			turmas: []TurmaCandidata{
+				{Componente: "Cálculo", Turma: "01", Horarios: []string{"24M12"}},
+				{Componente: "Cálculo", Turma: "02", Horarios: []string{"24M12"}},
+				{Componente: "Física", Horarios: []string{"2M2"}},
+				{Componente: "Química", Horarios: []string{"35T12"}},
+			},
+			conflitos:   [][]string{{"Cálculo - 01", "Cálculo - 02", "Física"}},
+			combinacoes: []int{},
+		},
+		{
+			nome: "alternativa resolve o choque",
+			turmas: []TurmaCandidata{
+				{Componente: "Cálculo", Turma: "01", Horarios: []string{"24M12"}},
+				{Componente: "Cálculo", Turma: "02", Horarios: []string{"35T1"}},
+				{Componente: "Física", Horarios: []string{"2M2"}},
+			},
+			conflitos:   [][]string{{"Cálculo - 01", "Física"}},
+			combinacoes: []int{3},
+		},
+		{
+			nome: "código inválido",
+			turmas: []TurmaCandidata{
+				{Componente: "Cálculo", Horarios: []string{"9M1"}},
+			},
+			erro: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			verificacao, err := VerificarHorarios(tt.turmas)
+			if tt.erro {
+				if err == nil {
+					t.Fatal("esperava erro")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var conflitos [][]string
+			for _, conflito := range verificacao.Conflitos {
+				conflitos = append(conflitos, conflito.Turmas)
+			}
+			if !slices.EqualFunc(conflitos, tt.conflitos, slices.Equal) {
+				t.Errorf("conflitos = %v, esperava %v", conflitos, tt.conflitos)
+			}
+
+			combinacoes := []int{}
+			for _, combinacao := range verificacao.Combinacoes {
+				combinacoes = append(combinacoes, combinacao.CargaHorariaSemanal)
+			}
+			if !slices.Equal(combinacoes, tt.combinacoes) {
+				t.Errorf("combinações = %v, esperava %v", combinacoes, tt.combinacoes)
+			}
+
+			switch {
+			case tt.cargaHoraria == nil && verificacao.CargaHorariaSemanal != nil:
+				t.Errorf("carga horária = %d, esperava nenhuma", *verificacao.CargaHorariaSemanal)
+			case tt.cargaHoraria != nil && (verificacao.CargaHorariaSemanal == nil || *verificacao.CargaHorariaSemanal != *tt.cargaHoraria):
+				t.Errorf("carga horária = %v, esperava %d", verificacao.CargaHorariaSemanal, *tt.cargaHoraria)
+			}
+		})
+	}
+}
+
+func TestGerarCombinacoesTruncado(t *testing.T) {
+	// 8 componentes com 2 turmas sem choque cada: 256 combinações, acima de MAX_COMBINACOES
+	var turmas []TurmaCandidata
+	for i, dia := range []string{"2", "3", "4", "5", "6", "7"} {
+		turmas = append(turmas,
+			TurmaCandidata{Componente: "C" + dia, Turma: "01", Horarios: []string{dia + "M1"}},
+			TurmaCandidata{Componente: "C" + dia, Turma: "02", Horarios: []string{dia + "T1"}},
+		)
+		if i < 2 {
+			turmas = append(turmas,
+				TurmaCandidata{Componente: "N" + dia, Turma: "01", Horarios: []string{dia + "N1"}},
+				TurmaCandidata{Componente: "N" + dia, Turma: "02", Horarios: []string{dia + "N2"}},
+			)
+		}
+	}
+
+	verificacao, err := VerificarHorarios(turmas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(verificacao.Combinacoes) != MAX_COMBINACOES || !verificacao.Truncado {
+		t.Errorf("%d combinações, truncado=%v; esperava %d e truncado", len(verificacao.Combinacoes), verificacao.Truncado, MAX_COMBINACOES)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Diretório dos dados persistidos pela API; pode ser trocado pela variável SIGAA_DATA_DIR
+const DATA_DIR_PADRAO = "data"
+
+var definicoesIndices = []DefinicaoIndice{
+	{"MC", "Média de Conclusão", "Média das notas finais dos componentes concluídos com aprovação, ponderada pela carga horária."},
+	{"MCN", "Média de Conclusão Normalizada", "MC comparada com a dos concluintes do mesmo curso: 500 é a média e cada 100 pontos, um desvio padrão."},
+	{"IRA", "Índice de Rendimento Acadêmico", "Média das notas finais de todos os componentes cursados, inclusive reprovações, ponderada pela carga horária."},
+	{"IECH", "Índice de Eficiência em Carga Horária", "Carga horária aprovada dividida pela carga horária utilizada (aprovações, reprovações e trancamentos)."},
+	{"IEPL", "Índice de Eficiência em Períodos Letivos", "Carga horária acumulada dividida pela carga horária esperada para os períodos já cursados."},
+	{"IEA", "Índice de Eficiência Acadêmica", "Produto MC × IECH × IEPL."},
+	{"IEAN", "Índice de Eficiência Acadêmica Normalizado", "Produto MCN × IECH × IEPL."},
+	{"IECHP", "Índice de Eficiência em Carga Horária no Período", "IECH calculado apenas com os componentes do último período letivo."},
+}
+
+// valoresIndices devolve, por sigla, só os índices que o portal mostrou
+func valoresIndices(indices IndicesAcademicos) map[string]float64 {
+	valores := map[string]float64{}
+	for sigla, valor := range map[string]*float64{
+		"MC":    indices.MC,
+		"MCN":   indices.MCN,
+		"IRA":   indices.IRA,
+		"IECH":  indices.IECH,
+		"IEPL":  indices.IEPL,
+		"IEA":   indices.IEA,
+		"IEAN":  indices.IEAN,
+		"IECHP": indices.IECHP,
+	} {
+		if valor != nil {
+			valores[sigla] = *valor
+		}
+	}
+	return valores
+}
+
+// IndicesStore guarda os snapshots de índices de cada discente num arquivo JSON.
+// O arquivo é nomeado pelo hash da matrícula, para não expor a matrícula no disco.
+type IndicesStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+var indicesStore = NewIndicesStore(dataDir())
+
+func dataDir() string {
+	if dir := os.Getenv("SIGAA_DATA_DIR"); dir != "" {
+		return dir
+	}
+	return DATA_DIR_PADRAO
+}
+
+func NewIndicesStore(dataDir string) *IndicesStore {
+	return &IndicesStore{dir: filepath.Join(dataDir, "indices")}
+}
+
+func (s *IndicesStore) arquivo(matricula string) string {
+	hash := sha256.Sum256([]byte(matricula))
+	return filepath.Join(s.dir, hex.EncodeToString(hash[:])+".json")
+}
+
+func (s *IndicesStore) ler(matricula string) ([]SnapshotIndices, error) {
+	snapshots := []SnapshotIndices{}
+	data, err := os.ReadFile(s.arquivo(matricula))
+	if errors.Is(err, os.ErrNotExist) {
+		return snapshots, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler histórico de índices: %w", err)
+	}
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return nil, fmt.Errorf("histórico de índices corrompido: %w", err)
+	}
+	return snapshots, nil
+}
+
+// Registrar acrescenta um snapshot, exceto quando os índices não mudaram desde o último.
+// Índices ausentes no portal ficam fora do snapshot, e não como zero.
+func (s *IndicesStore) Registrar(matricula string, indices IndicesAcademicos) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots, err := s.ler(matricula)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) > 0 && maps.Equal(valoresIndices(snapshots[len(snapshots)-1].Indices), valoresIndices(indices)) {
+		return nil
+	}
+	snapshots = append(snapshots, SnapshotIndices{Data: time.Now(), Indices: indices})
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("erro ao criar diretório de índices: %w", err)
+	}
+	data, err := json.Marshal(snapshots)
+	if err != nil {
+		return err
+	}
+	// Escreve num temporário e renomeia, para não deixar o arquivo pela metade
+	tmp := s.arquivo(matricula) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("erro ao gravar histórico de índices: %w", err)
+	}
+	return os.Rename(tmp, s.arquivo(matricula))
+}
+
+func (s *IndicesStore) Historico(matricula string) ([]SnapshotIndices, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ler(matricula)
+}
+
+// evolucaoIndices transforma os snapshots numa série temporal por sigla; um snapshot sem o índice não vira ponto
+func evolucaoIndices(snapshots []SnapshotIndices) map[string][]PontoIndice {
+	evolucao := map[string][]PontoIndice{}
+	for _, definicao := range definicoesIndices {
+		evolucao[definicao.Sigla] = []PontoIndice{}
+	}
+	for _, snapshot := range snapshots {
+		for sigla, valor := range valoresIndices(snapshot.Indices) {
+			evolucao[sigla] = append(evolucao[sigla], PontoIndice{Data: snapshot.Data, Valor: valor})
+		}
+	}
+	return evolucao
+}
+
+// GetHistoricoIndices registra os índices atuais do portal e devolve a série completa do discente
+func GetHistoricoIndices(jsessionid string) (IndicesAcademicos, []SnapshotIndices, string, string, error) {
+	var indices IndicesAcademicos
+	doc, newJsessionid, viewState, err := getPaginaPortal(jsessionid)
+	if err != nil {
+		return indices, nil, newJsessionid, "", err
+	}
+
+	matricula := parsePerfilPortal(doc).Matricula
+	if matricula == "" {
+		return indices, nil, newJsessionid, viewState, fmt.Errorf("não foi possível encontrar a matrícula no portal")
+	}
+	indices = parseIndices(doc)
+	if err := indicesStore.Registrar(matricula, indices); err != nil {
+		return indices, nil, newJsessionid, viewState, err
+	}
+
+	snapshots, err := indicesStore.Historico(matricula)
+	return indices, snapshots, newJsessionid, viewState, err
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Limite de páginas intermediárias seguidas, para não ficar preso num ciclo de avisos
+const MAX_INTERSTICIAIS = 5
+
+const INTERSTICIAL_AVALIACAO_INSTITUCIONAL = "avaliacao_institucional"
+
+// Intersticial descreve uma página que o SIGAA mostra no meio da navegação (avisos, questionários,
+// atualização de dados). Sem Continuar, a página exige ação do usuário no próprio SIGAA.
+type Intersticial struct {
+	Tipo       string
+	Reconhecer func(doc *goquery.Document, paginaUrl string) bool
+	Continuar  func(doc *goquery.Document, jsessionid, paginaUrl string) (*goquery.Document, string, error)
+}
+
+// AcaoNecessariaError indica que o SIGAA parou numa página que a API não pode resolver sozinha
+type AcaoNecessariaError struct {
+	Tipo      string   `json:"tipo"`
+	Titulo    string   `json:"titulo"`
+	Mensagens []string `json:"mensagens"`
+	Url       string   `json:"url"`
+}
+
+func (e *AcaoNecessariaError) Error() string {
+	if e.Titulo == "" {
+		return fmt.Sprintf("ação necessária no SIGAA (%s)", e.Tipo)
+	}
+	return fmt.Sprintf("ação necessária no SIGAA (%s): %s", e.Tipo, e.Titulo)
+}
+
+var intersticiais []Intersticial
+
+// RegistrarIntersticial acrescenta um tratador; os registrados antes têm prioridade
+func RegistrarIntersticial(intersticial Intersticial) {
+	intersticiais = append(intersticiais, intersticial)
+}
+
+func init() {
+	RegistrarIntersticial(Intersticial{
+		Tipo: "aviso_logon",
+		Reconhecer: func(doc *goquery.Document, paginaUrl string) bool {
+			return strings.Contains(paginaUrl+acoesFormularios(doc), "telaAvisoLogon")
+		},
+		Continuar: continuarPor("Continuar"),
+	})
+	RegistrarIntersticial(Intersticial{
+		Tipo: INTERSTICIAL_AVALIACAO_INSTITUCIONAL,
+		Reconhecer: func(doc *goquery.Document, paginaUrl string) bool {
+			// Só a cobrança do login oferece "Preencher depois"; a avaliação aberta pelo menu não é intermediária
+			return strings.Contains(paginaUrl+acoesFormularios(doc), "/avaliacao/") && findButtonName(doc.Selection, "depois") != ""
+		},
+		Continuar: continuarPor("depois"),
+	})
+	RegistrarIntersticial(Intersticial{
+		Tipo: "atualizacao_dados",
+		Reconhecer: func(doc *goquery.Document, paginaUrl string) bool {
+			titulo := normalizeText(tituloPagina(doc))
+			// "Meus dados pessoais", aberta pelo menu, não pede para atualizar e não deve ser pulada
+			return strings.Contains(paginaUrl+acoesFormularios(doc), "atualizacaoDados") || strings.Contains(titulo, "atualize seus dados")
+		},
+		Continuar: continuarPor("depois", "Pular"),
+	})
+	RegistrarIntersticial(Intersticial{
+		Tipo: "questionario_obrigatorio",
+		Reconhecer: func(doc *goquery.Document, paginaUrl string) bool {
+			return strings.Contains(strings.ToLower(paginaUrl), "questionario") && !strings.Contains(paginaUrl, "/ava/") &&
+				strings.Contains(normalizeText(doc.Text()), "obrigatori")
+		},
+	})
+	RegistrarIntersticial(Intersticial{
+		Tipo: "comunicado",
+		Reconhecer: func(doc *goquery.Document, paginaUrl string) bool {
+			paginas := paginaUrl + acoesFormularios(doc)
+			return (strings.Contains(paginas, "comunicado") || strings.Contains(paginas, "telaAviso")) && doc.Find("#agenda-docente").Length() == 0
+		},
+		Continuar: continuarPor("Ciente", "Continuar", "Fechar"),
+	})
+}
+
+// acoesFormularios junta as actions dos formulários da página. Respostas de POST costumam vir
+// por forward, com a URL do pedido, e só a action denuncia qual página foi servida.
+func acoesFormularios(doc *goquery.Document) string {
+	var acoes []string
+	doc.Find("form[action]").Each(func(i int, form *goquery.Selection) {
+		acoes = append(acoes, form.AttrOr("action", ""))
+	})
+	return " " + strings.Join(acoes, " ")
+}
+
+func tituloPagina(doc *goquery.Document) string {
+	titulo := strings.TrimSpace(doc.Find("h2").First().Text())
+	if titulo == "" {
+		titulo = strings.TrimSpace(doc.Find("title").First().Text())
+	}
+	return strings.Join(strings.Fields(titulo), " ")
+}
+
+// continuarPor monta um Continuar que clica no primeiro botão encontrado entre os rótulos, em ordem.
+// Sem nenhum deles na página, a continuação não é segura e o usuário precisa agir.
+func continuarPor(rotulos ...string) func(doc *goquery.Document, jsessionid, paginaUrl string) (*goquery.Document, string, error) {
+	return func(doc *goquery.Document, jsessionid, paginaUrl string) (*goquery.Document, string, error) {
+		for _, rotulo := range rotulos {
+			var form *goquery.Selection
+			var botao string
+			doc.Find("form").EachWithBreak(func(i int, f *goquery.Selection) bool {
+				if name := findButtonName(f, rotulo); name != "" {
+					form, botao = f, name
+					return false
+				}
+				return true
+			})
+			if form == nil {
+				continue
+			}
+
+			payload := formPayload(form)
+			payload.Set(botao, form.Find("[name='"+botao+"']").AttrOr("value", rotulo))
+			actionUrl := resolveActionUrl(paginaUrl, form.AttrOr("action", ""))
+			docSeguinte, newJsessionid, err := requisitarPagina("POST", actionUrl, jsessionid, paginaUrl, strings.NewReader(payload.Encode()), "application/x-www-form-urlencoded")
+			if err != nil {
+				return nil, newJsessionid, fmt.Errorf("erro ao sair da página intermediária: %w", err)
+			}
+			return docSeguinte, newJsessionid, nil
+		}
+		return nil, jsessionid, nil
+	}
+}
+
+// tratarIntersticiais passa pelas páginas intermediárias reconhecidas até chegar a uma página comum e
+// devolve os tipos das que foram puladas, para quem precisar avisar o usuário (a avaliação institucional
+// adiada no login, por exemplo). Quando uma delas não pode ser pulada, ou continuar é falso, devolve um
+// *AcaoNecessariaError com os detalhes da página.
+func tratarIntersticiais(doc *goquery.Document, jsessionid string, continuar bool) (*goquery.Document, string, []string, error) {
+	var pulados []string
+	for i := 0; i < MAX_INTERSTICIAIS; i++ {
+		paginaUrl := ""
+		if doc.Url != nil {
+			paginaUrl = doc.Url.String()
+		}
+
+		var encontrado *Intersticial
+		for j := range intersticiais {
+			if intersticiais[j].Reconhecer(doc, paginaUrl) {
+				encontrado = &intersticiais[j]
+				break
+			}
+		}
+		if encontrado == nil {
+			return doc, jsessionid, pulados, nil
+		}
+
+		acao := &AcaoNecessariaError{Tipo: encontrado.Tipo, Titulo: tituloPagina(doc), Url: paginaUrl}
+		info, erros := parseMensagensSigaa(doc)
+		acao.Mensagens = append(erros, info...)
+		if encontrado.Continuar == nil || !continuar {
+			return nil, jsessionid, pulados, acao
+		}
+
+		fmt.Printf("Página intermediária '%s' detectada em %s, continuando.\n", encontrado.Tipo, paginaUrl)
+		docSeguinte, newJsessionid, err := encontrado.Continuar(doc, jsessionid, paginaUrl)
+		if err != nil {
+			return nil, newJsessionid, pulados, err
+		}
+		if docSeguinte == nil {
+			return nil, newJsessionid, pulados, acao
+		}
+		pulados = append(pulados, encontrado.Tipo)
+		doc, jsessionid = docSeguinte, newJsessionid
+	}
+	return nil, jsessionid, pulados, fmt.Errorf("o SIGAA mostrou mais de %d páginas intermediárias seguidas", MAX_INTERSTICIAIS)
+}
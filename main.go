@@ -1,12 +1,16 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"mime"
 	"net/http"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gin-gonic/gin"
@@ -33,16 +37,20 @@ func main() {
 		AllowOrigins:     []string{"https://conecta-ufrpe.vercel.app", "http://localhost:4200", "https://mozilla.github.io"},
 		AllowMethods:     []string{"GET", "POST", "OPTIONS"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Disposition", "X-Jsessionid"},
 		AllowCredentials: true,
 	}))
 
 	router.GET("/ping", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"message": "pong"})
 	})
+	router.GET("/status", handleGetStatus)
 
 	router.GET("/calendario", handleGetCalendario)
 	router.GET("/calendario/url", handleGetCalendarioURL)
+	router.GET("/turmas-abertas", handleGetTurmasAbertas)
+	router.POST("/horarios/conflitos", handlePostConflitosHorario)
+	router.GET("/biblioteca/acervo", handleGetAcervo)
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -50,24 +58,67 @@ func main() {
 	api.Use(AuthMiddleware())
 	{
 		api.GET("/main-data", handleGetMainData)
+		api.GET("/perfil", handleGetPerfil)
+		api.GET("/perfil/foto", handleGetFotoPerfil)
+		api.GET("/atividades-complementares", handleGetAtividadesComplementares)
+		api.GET("/avaliacao-institucional", handleGetAvaliacaoInstitucional)
+		api.GET("/indices/historico", handleGetHistoricoIndices)
+		api.POST("/indices/projecao", handlePostProjecaoIndices)
 		api.POST("/notas", handlePostNotas)
 		api.POST("/turma", handlePostTurma)
+		api.POST("/turma/participantes", handlePostParticipantes)
+		api.POST("/turma/materiais", handlePostMateriais)
+		api.POST("/turma/materiais/download", handlePostDownloadMaterial)
+		api.POST("/turma/materiais/zip", handlePostZipMateriais)
+		api.POST("/turma/tarefas", handlePostTarefas)
+		api.POST("/turma/tarefas/enviar", handlePostEnviarTarefa)
+		api.POST("/turma/noticias", handlePostNoticias)
+		api.POST("/turma/noticias/detalhe", handlePostNoticiaDetalhe)
+		api.POST("/turma/foruns", handlePostForuns)
+		api.POST("/turma/foruns/topicos", handlePostTopicosForum)
+		api.POST("/turma/foruns/mensagens", handlePostMensagensForum)
+		api.POST("/turma/foruns/publicar", handlePostPublicarForum)
+		api.POST("/turma/questionarios", handlePostQuestionarios)
+		api.POST("/turma/questionarios/questoes", handlePostQuestoesQuestionario)
+		api.POST("/turma/questionarios/resultado", handlePostResultadoQuestionario)
+		api.POST("/turma/questionarios/responder", handlePostResponderQuestionario)
+		api.POST("/turma/enquetes", handlePostEnquetes)
+		api.POST("/turma/enquetes/detalhe", handlePostDetalheEnquete)
+		api.POST("/turma/enquetes/votar", handlePostVotarEnquete)
+		api.GET("/mensagens", handleGetMensagens)
+		api.GET("/mensagens/:id", handleGetMensagem)
+		api.POST("/mensagens/:id/lida", handlePostMarcarMensagemLida)
+		api.POST("/mensagens/enviar", handlePostEnviarMensagem)
+		api.POST("/biblioteca/emprestimos", handlePostEmprestimos)
+		api.POST("/biblioteca/renovar", handlePostRenovarEmprestimos)
+		api.POST("/matricula", handlePostEstadoMatricula)
+		api.POST("/matricula/turmas-ofertadas", handlePostTurmasOfertadas)
+		api.POST("/matricula/comprovante", handlePostComprovanteMatricula)
+		api.POST("/matricula/submeter", handlePostSubmeterMatricula)
+		api.POST("/matricula/resultado", handlePostResultadoMatricula)
+		api.POST("/trancamento", handlePostTrancamento)
+		api.POST("/trancamento/solicitar", handlePostSolicitarTrancamento)
 	}
 
 	router.POST("/login", handleLogin)
 
+	IniciarVerificacaoSigaa(INTERVALO_VERIFICACAO_SIGAA)
+
 	log.Println("🚀 Servidor rodando em http://localhost:8080")
 	router.Run(":8080")
 }
 
 // @Summary Faz login no SIGAA
+// @Description avaliacaoInstitucionalPendente indica que o SIGAA cobrou a avaliação institucional, adiada para permitir o login.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param credentials body LoginRequest true "Credenciais do usuário"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 428 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
 // @Router /login [post]
 func handleLogin(c *gin.Context) {
 	var req LoginRequest
@@ -76,34 +127,54 @@ func handleLogin(c *gin.Context) {
 		return
 	}
 
-	jsessionid, err := repeatLoginReq(req.Username, req.Password, 0)
+	jsessionid, avaliacaoPendente, err := repeatLoginReq(req.Username, req.Password)
 	if err != nil {
 		fmt.Println(err)
+		var acao *AcaoNecessariaError
 		if errors.Is(err, ErrInvalidCredentials) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("Falha no login: %s", err)})
+		} else if errors.As(err, &acao) {
+			c.JSON(http.StatusPreconditionRequired, gin.H{"error": "Resolva a pendência no SIGAA antes de entrar: " + acao.Error(), "acaoNecessaria": acao})
+		} else if errors.Is(err, ErrSigaaIndisponivel) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "O SIGAA está indisponível no momento. Tente novamente mais tarde.", "status": monitorSigaa.Status()})
 		} else {
 			c.JSON(http.StatusBadGateway, gin.H{"error": "Falha ao se comunicar com o SIGAA. Tente novamente mais tarde."})
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"jsessionid": jsessionid})
+	c.JSON(http.StatusOK, gin.H{"jsessionid": jsessionid, "avaliacaoInstitucionalPendente": avaliacaoPendente})
 }
 
-func repeatLoginReq(username string, password string, count int) (string, error) {
-	jsessionid, err := Login(username, password)
+// repeatLoginReq repete o login inteiro em falhas inesperadas do SIGAA (formulário ausente, resposta
+// truncada). Indisponibilidade já é repetida requisição a requisição, e credenciais ou pendências não mudam.
+func repeatLoginReq(username string, password string) (string, bool, error) {
+	var jsessionid string
+	var avaliacaoPendente bool
+	err := politicaLogin.repetir("Login", func(tentativa int) error {
+		var err error
+		jsessionid, avaliacaoPendente, err = Login(username, password)
+		return err
+	}, func(err error) (bool, time.Duration) {
+		var acao *AcaoNecessariaError
+		return !errors.Is(err, ErrInvalidCredentials) && !errors.As(err, &acao) && !errors.Is(err, ErrSigaaIndisponivel), 0
+	})
 	if err != nil {
 		fmt.Println(err)
-		if errors.Is(err, ErrInvalidCredentials) {
-			return "", ErrInvalidCredentials
-		} else {
-			if count >= 5 {
-				return "", err
-			}
-			return repeatLoginReq(username, password, count+1)
-		}
+		return "", false, err
 	}
-	return jsessionid, nil
+	return jsessionid, avaliacaoPendente, nil
+}
+
+// @Summary Informa se o SIGAA está disponível e a latência recente
+// @Description Status: online, instavel, manutencao, fora_do_ar ou desconhecido (antes da primeira verificação).
+// @Tags Status
+// @Produce json
+// @Success 200 {object} StatusSigaa
+// @Router /status [get]
+func handleGetStatus(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, monitorSigaa.Status())
 }
 
 // @Summary Retorna dados principais (nome e turmas)
@@ -111,6 +182,8 @@ func repeatLoginReq(username string, password string, count int) (string, error)
 // @Produce json
 // @Success 200 {object} map[string]interface{}
 // @Failure 401 {object} map[string]string
+// @Failure 428 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /main-data [get]
 // @Security BearerAuth
 func handleGetMainData(c *gin.Context) {
@@ -118,7 +191,7 @@ func handleGetMainData(c *gin.Context) {
 
 	nome, ch, indices, avaliacoes, turmas, newJsessionid, viewState, err := GetMainData(jsessionid)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Sessão expirada ou inválida"})
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar dados principais: " + err.Error()})
 		return
 	}
 
@@ -133,6 +206,166 @@ func handleGetMainData(c *gin.Context) {
 	})
 }
 
+// @Summary Retorna o perfil do discente (matrícula, curso, nível, status, ingresso, e-mail e campus)
+// @Tags SIGAA
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /perfil [get]
+// @Security BearerAuth
+func handleGetPerfil(c *gin.Context) {
+	jsessionid := c.GetString("jsessionid")
+	perfil, newJsessionid, viewState, err := GetPerfil(jsessionid)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar perfil: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"perfil":     perfil,
+		"jsessionid": newJsessionid,
+		"viewState":  viewState,
+	})
+}
+
+// @Summary Retorna a foto do perfil do discente
+// @Description O novo jsessionid vem no cabeçalho X-Jsessionid.
+// @Tags SIGAA
+// @Produce image/jpeg
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /perfil/foto [get]
+// @Security BearerAuth
+func handleGetFotoPerfil(c *gin.Context) {
+	jsessionid := c.GetString("jsessionid")
+	resp, newJsessionid, err := BaixarFotoPerfil(jsessionid)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao buscar foto: " + err.Error()})
+		return
+	}
+	defer resp.Body.Close()
+
+	c.Header("X-Jsessionid", newJsessionid)
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.DataFromReader(http.StatusOK, resp.ContentLength, resp.Header.Get("Content-Type"), resp.Body, nil)
+}
+
+// @Summary Lista as atividades complementares e os totais por categoria frente ao exigido pelo currículo
+// @Tags SIGAA
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /atividades-complementares [get]
+// @Security BearerAuth
+func handleGetAtividadesComplementares(c *gin.Context) {
+	jsessionid := c.GetString("jsessionid")
+	resumo, newJsessionid, viewState, err := GetAtividadesComplementares(jsessionid)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar atividades complementares: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"atividades":       resumo.Atividades,
+		"categorias":       resumo.Categorias,
+		"totalAproveitado": resumo.TotalAproveitado,
+		"totalExigido":     resumo.TotalExigido,
+		"totalPendente":    resumo.TotalPendente,
+		"jsessionid":       newJsessionid,
+		"viewState":        viewState,
+	})
+}
+
+// @Summary Informa se a avaliação institucional está pendente e lista os formulários a preencher
+// @Tags SIGAA
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /avaliacao-institucional [get]
+// @Security BearerAuth
+func handleGetAvaliacaoInstitucional(c *gin.Context) {
+	jsessionid := c.GetString("jsessionid")
+	status, newJsessionid, viewState, err := GetStatusAvaliacaoInstitucional(jsessionid)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar avaliação institucional: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"avaliacao":  status,
+		"jsessionid": newJsessionid,
+		"viewState":  viewState,
+	})
+}
+
+// @Summary Retorna os índices acadêmicos atuais, a evolução de cada um e o que significam
+// @Description Cada consulta a /main-data ou a esta rota grava um snapshot quando algum índice muda.
+// @Tags SIGAA
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /indices/historico [get]
+// @Security BearerAuth
+func handleGetHistoricoIndices(c *gin.Context) {
+	jsessionid := c.GetString("jsessionid")
+	indices, snapshots, newJsessionid, viewState, err := GetHistoricoIndices(jsessionid)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar histórico de índices: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"indices":    indices,
+		"evolucao":   evolucaoIndices(snapshots),
+		"definicoes": definicoesIndices,
+		"jsessionid": newJsessionid,
+		"viewState":  viewState,
+	})
+}
+
+type ProjecaoIndicesRequest struct {
+	Turmas           []ResultadoHipotetico `json:"turmas" binding:"dive"`
+	NotaPadrao       *float64              `json:"notaPadrao"`
+	ChAprovada       int                   `json:"chAprovada"`
+	ChUtilizada      int                   `json:"chUtilizada"`
+	PeriodosCursados int                   `json:"periodosCursados"`
+}
+
+// @Summary Projeta MC, IRA e demais índices com notas hipotéticas para as turmas atuais
+// @Description notaPadrao aplica a mesma nota às turmas do período não listadas em turmas.
+// @Description Cada item de turmas exige turma e nota; o nome deve ser o da turma ou um trecho que identifique uma só.
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body ProjecaoIndicesRequest true "Resultados hipotéticos e, opcionalmente, cargas horárias acumuladas"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /indices/projecao [post]
+// @Security BearerAuth
+func handlePostProjecaoIndices(c *gin.Context) {
+	var req ProjecaoIndicesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	base := BaseProjecao{ChAprovada: req.ChAprovada, ChUtilizada: req.ChUtilizada, PeriodosCursados: req.PeriodosCursados}
+	jsessionid := c.GetString("jsessionid")
+	projecao, newJsessionid, viewState, err := ProjetarIndices(req.Turmas, req.NotaPadrao, base, jsessionid)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao projetar índices: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"projecao":   projecao,
+		"jsessionid": newJsessionid,
+		"viewState":  viewState,
+	})
+}
+
 type TurmaPostRequest struct {
 	Turma     TurmaData `json:"turma" binding:"required"`
 	ViewState string    `json:"viewState" binding:"required"`
@@ -159,7 +392,7 @@ func handlePostTurma(c *gin.Context) {
 	jsessionid := c.GetString("jsessionid")
 	turmaAtualizada, newJsessionid, newViewState, err := GetTurmaData(req.Turma, jsessionid, req.ViewState)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar dados da turma: " + err.Error()})
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar dados da turma: " + err.Error()})
 		return
 	}
 
@@ -170,44 +403,1363 @@ func handlePostTurma(c *gin.Context) {
 	})
 }
 
-type NotasRequest struct {
-	ViewState string `json:"viewState" binding:"required"`
+// @Summary Lista docentes e discentes da turma virtual
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body TurmaPostRequest true "Turma e viewState"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /turma/participantes [post]
+// @Security BearerAuth
+func handlePostParticipantes(c *gin.Context) {
+	var req TurmaPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	participantes, newJsessionid, newViewState, err := GetParticipantes(req.Turma, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar participantes: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"participantes": participantes,
+		"jsessionid":    newJsessionid,
+		"viewState":     newViewState,
+	})
 }
 
-// @Summary Baixa o HTML contendo notas
+// @Summary Lista arquivos, links e vídeos de cada tópico da turma virtual
 // @Tags SIGAA
 // @Accept json
 // @Produce json
-// @Param body body NotasRequest true "ViewState atual"
-// @Success 200 {object} map[string]string
+// @Param body body TurmaPostRequest true "Turma e viewState"
+// @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Router /notas [post]
+// @Failure 500 {object} map[string]string
+// @Router /turma/materiais [post]
 // @Security BearerAuth
-func handlePostNotas(c *gin.Context) {
-	var req NotasRequest
+func handlePostMateriais(c *gin.Context) {
+	var req TurmaPostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
 		return
 	}
 
 	jsessionid := c.GetString("jsessionid")
+	materiais, newJsessionid, newViewState, err := GetMateriais(req.Turma, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar materiais: " + err.Error()})
+		return
+	}
 
-	// Chama sua função real
-	notas, newJsessionid, newViewState, err := GetNotas(jsessionid, req.ViewState)
+	c.JSON(http.StatusOK, gin.H{
+		"materiais":  materiais,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+type DownloadMaterialRequest struct {
+	Turma      TurmaData `json:"turma" binding:"required"`
+	ViewState  string    `json:"viewState" binding:"required"`
+	MaterialId string    `json:"materialId" binding:"required"`
+}
+
+// @Summary Baixa um arquivo da turma virtual pela sessão do usuário
+// @Description O novo jsessionid vem no cabeçalho X-Jsessionid; o viewState enviado continua válido.
+// @Tags SIGAA
+// @Accept json
+// @Produce octet-stream
+// @Param body body DownloadMaterialRequest true "Turma, viewState e id do material"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /turma/materiais/download [post]
+// @Security BearerAuth
+func handlePostDownloadMaterial(c *gin.Context) {
+	var req DownloadMaterialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	resp, material, newJsessionid, err := BaixarMaterial(req.Turma, req.MaterialId, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao baixar material: " + err.Error()})
+		return
+	}
+	defer resp.Body.Close()
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("X-Jsessionid", newJsessionid)
+	c.DataFromReader(http.StatusOK, resp.ContentLength, contentType, resp.Body, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": nomeArquivo(resp, material)}),
+	})
+}
+
+type ZipMateriaisRequest struct {
+	Turma     TurmaData `json:"turma" binding:"required"`
+	ViewState string    `json:"viewState" binding:"required"`
+	LimiteMb  int       `json:"limiteMb"`
+}
+
+// @Summary Baixa todos os arquivos da turma virtual num ZIP organizado por tópico
+// @Description Inclui um manifest.json com o status de cada material. O limite padrão e máximo é de 200 MB.
+// @Tags SIGAA
+// @Accept json
+// @Produce application/zip
+// @Param body body ZipMateriaisRequest true "Turma, viewState e limite em MB"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /turma/materiais/zip [post]
+// @Security BearerAuth
+func handlePostZipMateriais(c *gin.Context) {
+	var req ZipMateriaisRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+	limiteMb := req.LimiteMb
+	if limiteMb <= 0 || limiteMb > MAX_ZIP_MATERIAIS_MB {
+		limiteMb = MAX_ZIP_MATERIAIS_MB
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	exportacao, err := PrepararExportacaoMateriais(req.Turma, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao acessar materiais da turma: " + err.Error()})
+		return
+	}
+
+	arquivo, tamanho, err := GerarZipMateriais(&exportacao, int64(limiteMb)*1024*1024)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao gerar ZIP de materiais: " + err.Error()})
+		return
+	}
+	defer os.Remove(arquivo.Name())
+	defer arquivo.Close()
+
+	c.Header("X-Jsessionid", exportacao.Jsessionid)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": req.Turma.Nome + ".zip"}))
+	c.DataFromReader(http.StatusOK, tamanho, "application/zip", arquivo, nil)
+}
+
+// @Summary Lista as tarefas da turma virtual com prazos, situação do envio e nota
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body TurmaPostRequest true "Turma e viewState"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /turma/tarefas [post]
+// @Security BearerAuth
+func handlePostTarefas(c *gin.Context) {
+	var req TurmaPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	tarefas, newJsessionid, newViewState, err := GetTarefas(req.Turma, jsessionid, req.ViewState)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Sessão expirada ou inválida"})
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar tarefas: " + err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":    "HTML de notas baixado com sucesso!",
+		"tarefas":    tarefas,
 		"jsessionid": newJsessionid,
 		"viewState":  newViewState,
-		"notas":      notas,
 	})
 }
 
+// @Summary Envia o arquivo de uma tarefa da turma virtual
+// @Tags SIGAA
+// @Accept multipart/form-data
+// @Produce json
+// @Param turma formData string true "TurmaData em JSON"
+// @Param viewState formData string true "ViewState atual"
+// @Param tarefaId formData string true "Id da tarefa"
+// @Param comentario formData string false "Comentário do envio"
+// @Param arquivo formData file true "Arquivo da tarefa"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /turma/tarefas/enviar [post]
+// @Security BearerAuth
+func handlePostEnviarTarefa(c *gin.Context) {
+	var turma TurmaData
+	if err := json.Unmarshal([]byte(c.PostForm("turma")), &turma); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Campo turma inválido: " + err.Error()})
+		return
+	}
+	viewState := c.PostForm("viewState")
+	tarefaId := c.PostForm("tarefaId")
+	if viewState == "" || tarefaId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "viewState e tarefaId são obrigatórios"})
+		return
+	}
+
+	header, err := c.FormFile("arquivo")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Arquivo ausente: " + err.Error()})
+		return
+	}
+	if err := ValidarArquivoTarefa(header.Filename, header.Size); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Arquivo inválido: " + err.Error()})
+		return
+	}
+	arquivo, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao ler arquivo: " + err.Error()})
+		return
+	}
+	defer arquivo.Close()
+
+	jsessionid := c.GetString("jsessionid")
+	confirmacao, newJsessionid, newViewState, err := EnviarTarefa(turma, tarefaId, c.PostForm("comentario"), header.Filename, arquivo, jsessionid, viewState)
+	aviso, err := avisoRetornoPortal(err)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao enviar tarefa: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"confirmacao": confirmacao,
+		"aviso":       aviso,
+		"jsessionid":  newJsessionid,
+		"viewState":   newViewState,
+	})
+}
+
+type NoticiasRequest struct {
+	Turma     TurmaData `json:"turma" binding:"required"`
+	ViewState string    `json:"viewState" binding:"required"`
+	Pagina    int       `json:"pagina"`
+	PorPagina int       `json:"porPagina"`
+}
+
+// @Summary Lista, paginadas, todas as notícias da turma virtual
+// @Description A paginação é feita pela API sobre a listagem completa; cada página consulta o SIGAA de novo.
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body NoticiasRequest true "Turma, viewState e paginação"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /turma/noticias [post]
+// @Security BearerAuth
+func handlePostNoticias(c *gin.Context) {
+	var req NoticiasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+	if req.Pagina <= 0 {
+		req.Pagina = 1
+	}
+	if req.PorPagina <= 0 {
+		req.PorPagina = NOTICIAS_POR_PAGINA
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	noticias, total, newJsessionid, newViewState, err := GetNoticias(req.Turma, req.Pagina, req.PorPagina, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar notícias: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"noticias":   noticias,
+		"pagina":     req.Pagina,
+		"porPagina":  req.PorPagina,
+		"total":      total,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+type NoticiaDetalheRequest struct {
+	Turma     TurmaData `json:"turma" binding:"required"`
+	ViewState string    `json:"viewState" binding:"required"`
+	NoticiaId string    `json:"noticiaId" binding:"required"`
+}
+
+// @Summary Retorna uma notícia da turma virtual com o conteúdo em texto rico
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body NoticiaDetalheRequest true "Turma, viewState e id da notícia"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /turma/noticias/detalhe [post]
+// @Security BearerAuth
+func handlePostNoticiaDetalhe(c *gin.Context) {
+	var req NoticiaDetalheRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	noticia, newJsessionid, newViewState, err := GetNoticiaDetalhe(req.Turma, req.NoticiaId, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar notícia: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"noticia":    noticia,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+// @Summary Lista os fóruns da turma virtual
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body TurmaPostRequest true "Turma e viewState"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /turma/foruns [post]
+// @Security BearerAuth
+func handlePostForuns(c *gin.Context) {
+	var req TurmaPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	foruns, newJsessionid, newViewState, err := GetForuns(req.Turma, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar fóruns: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"foruns":     foruns,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+type ForumRequest struct {
+	Turma     TurmaData `json:"turma" binding:"required"`
+	ViewState string    `json:"viewState" binding:"required"`
+	ForumId   string    `json:"forumId" binding:"required"`
+	TopicoId  string    `json:"topicoId"`
+}
+
+// @Summary Lista os tópicos de um fórum da turma virtual
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body ForumRequest true "Turma, viewState e id do fórum"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /turma/foruns/topicos [post]
+// @Security BearerAuth
+func handlePostTopicosForum(c *gin.Context) {
+	var req ForumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	topicos, newJsessionid, newViewState, err := GetTopicosForum(req.Turma, req.ForumId, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar tópicos do fórum: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"topicos":    topicos,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+// @Summary Lista as mensagens de um tópico de fórum, com o conteúdo em Markdown
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body ForumRequest true "Turma, viewState, id do fórum e do tópico"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /turma/foruns/mensagens [post]
+// @Security BearerAuth
+func handlePostMensagensForum(c *gin.Context) {
+	var req ForumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+	if req.TopicoId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "topicoId é obrigatório"})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	mensagens, newJsessionid, newViewState, err := GetMensagensForum(req.Turma, req.ForumId, req.TopicoId, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar mensagens do fórum: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"mensagens":  mensagens,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+type PublicarForumRequest struct {
+	Turma     TurmaData `json:"turma" binding:"required"`
+	ViewState string    `json:"viewState" binding:"required"`
+	ForumId   string    `json:"forumId" binding:"required"`
+	TopicoId  string    `json:"topicoId"`
+	Titulo    string    `json:"titulo"`
+	Mensagem  string    `json:"mensagem" binding:"required"`
+}
+
+// @Summary Responde a um tópico ou cria um tópico novo (sem topicoId) num fórum da turma
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body PublicarForumRequest true "Fórum, tópico e mensagem"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /turma/foruns/publicar [post]
+// @Security BearerAuth
+func handlePostPublicarForum(c *gin.Context) {
+	var req PublicarForumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+	if req.TopicoId == "" && req.Titulo == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Informe topicoId para responder ou titulo para criar um tópico"})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	confirmacao, newJsessionid, newViewState, err := PublicarForum(req.Turma, req.ForumId, req.TopicoId, req.Titulo, req.Mensagem, jsessionid, req.ViewState)
+	aviso, err := avisoRetornoPortal(err)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao publicar no fórum: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"confirmacao": confirmacao,
+		"aviso":       aviso,
+		"jsessionid":  newJsessionid,
+		"viewState":   newViewState,
+	})
+}
+
+// @Summary Lista os questionários da turma virtual com período e tentativas
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body TurmaPostRequest true "Turma e viewState"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /turma/questionarios [post]
+// @Security BearerAuth
+func handlePostQuestionarios(c *gin.Context) {
+	var req TurmaPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	questionarios, newJsessionid, newViewState, err := GetQuestionarios(req.Turma, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar questionários: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"questionarios": questionarios,
+		"jsessionid":    newJsessionid,
+		"viewState":     newViewState,
+	})
+}
+
+type QuestionarioRequest struct {
+	Turma          TurmaData `json:"turma" binding:"required"`
+	ViewState      string    `json:"viewState" binding:"required"`
+	QuestionarioId string    `json:"questionarioId" binding:"required"`
+}
+
+// @Summary Retorna as questões de um questionário aberto
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body QuestionarioRequest true "Turma, viewState e id do questionário"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /turma/questionarios/questoes [post]
+// @Security BearerAuth
+func handlePostQuestoesQuestionario(c *gin.Context) {
+	var req QuestionarioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	questoes, newJsessionid, newViewState, err := GetQuestoesQuestionario(req.Turma, req.QuestionarioId, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar questões: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"questoes":   questoes,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+// @Summary Retorna nota e feedback já liberados de um questionário
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body QuestionarioRequest true "Turma, viewState e id do questionário"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /turma/questionarios/resultado [post]
+// @Security BearerAuth
+func handlePostResultadoQuestionario(c *gin.Context) {
+	var req QuestionarioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	resultado, newJsessionid, newViewState, err := GetResultadoQuestionario(req.Turma, req.QuestionarioId, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar resultado: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"resultado":  resultado,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+type ResponderQuestionarioRequest struct {
+	Turma          TurmaData           `json:"turma" binding:"required"`
+	ViewState      string              `json:"viewState" binding:"required"`
+	QuestionarioId string              `json:"questionarioId" binding:"required"`
+	Respostas      map[string][]string `json:"respostas" binding:"required"`
+}
+
+// @Summary Envia as respostas de um questionário (id da questão -> valores escolhidos)
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body ResponderQuestionarioRequest true "Questionário e respostas"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /turma/questionarios/responder [post]
+// @Security BearerAuth
+func handlePostResponderQuestionario(c *gin.Context) {
+	var req ResponderQuestionarioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	confirmacao, newJsessionid, newViewState, err := ResponderQuestionario(req.Turma, req.QuestionarioId, req.Respostas, jsessionid, req.ViewState)
+	aviso, err := avisoRetornoPortal(err)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao responder questionário: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"confirmacao": confirmacao,
+		"aviso":       aviso,
+		"jsessionid":  newJsessionid,
+		"viewState":   newViewState,
+	})
+}
+
+// @Summary Lista as enquetes abertas e encerradas da turma virtual
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body TurmaPostRequest true "Turma e viewState"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /turma/enquetes [post]
+// @Security BearerAuth
+func handlePostEnquetes(c *gin.Context) {
+	var req TurmaPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	enquetes, newJsessionid, newViewState, err := GetEnquetes(req.Turma, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar enquetes: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"enquetes":   enquetes,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+type EnqueteRequest struct {
+	Turma     TurmaData `json:"turma" binding:"required"`
+	ViewState string    `json:"viewState" binding:"required"`
+	EnqueteId string    `json:"enqueteId" binding:"required"`
+	Opcao     string    `json:"opcao"`
+}
+
+// @Summary Retorna as opções de uma enquete e, se visível, o resultado
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body EnqueteRequest true "Turma, viewState e id da enquete"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /turma/enquetes/detalhe [post]
+// @Security BearerAuth
+func handlePostDetalheEnquete(c *gin.Context) {
+	var req EnqueteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	detalhe, newJsessionid, newViewState, err := GetDetalheEnquete(req.Turma, req.EnqueteId, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar enquete: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"enquete":    detalhe,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+// @Summary Vota numa enquete aberta da turma virtual
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body EnqueteRequest true "Turma, viewState, id da enquete e valor da opção"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /turma/enquetes/votar [post]
+// @Security BearerAuth
+func handlePostVotarEnquete(c *gin.Context) {
+	var req EnqueteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+	if req.Opcao == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "opcao é obrigatória"})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	confirmacao, newJsessionid, newViewState, err := VotarEnquete(req.Turma, req.EnqueteId, req.Opcao, jsessionid, req.ViewState)
+	aviso, err := avisoRetornoPortal(err)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao votar na enquete: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"confirmacao": confirmacao,
+		"aviso":       aviso,
+		"jsessionid":  newJsessionid,
+		"viewState":   newViewState,
+	})
+}
+
+// @Summary Lista as mensagens da caixa postal do SIGAA
+// @Tags SIGAA
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /mensagens [get]
+// @Security BearerAuth
+func handleGetMensagens(c *gin.Context) {
+	jsessionid := c.GetString("jsessionid")
+	mensagens, newJsessionid, err := GetCaixaPostal(jsessionid)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar caixa postal: " + err.Error()})
+		return
+	}
+
+	naoLidas := 0
+	for _, mensagem := range mensagens {
+		if !mensagem.Lida {
+			naoLidas++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"mensagens":  mensagens,
+		"naoLidas":   naoLidas,
+		"jsessionid": newJsessionid,
+	})
+}
+
+// @Summary Lê uma mensagem da caixa postal
+// @Tags SIGAA
+// @Produce json
+// @Param id path string true "Id da mensagem"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /mensagens/{id} [get]
+// @Security BearerAuth
+func handleGetMensagem(c *gin.Context) {
+	jsessionid := c.GetString("jsessionid")
+	mensagem, newJsessionid, err := GetMensagemCaixaPostal(c.Param("id"), jsessionid)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao ler mensagem: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"mensagem":   mensagem,
+		"jsessionid": newJsessionid,
+	})
+}
+
+// @Summary Marca uma mensagem da caixa postal como lida
+// @Tags SIGAA
+// @Produce json
+// @Param id path string true "Id da mensagem"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /mensagens/{id}/lida [post]
+// @Security BearerAuth
+func handlePostMarcarMensagemLida(c *gin.Context) {
+	jsessionid := c.GetString("jsessionid")
+	newJsessionid, err := MarcarMensagemLida(c.Param("id"), jsessionid)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao marcar mensagem como lida: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jsessionid": newJsessionid,
+	})
+}
+
+type EnviarMensagemRequest struct {
+	Turma     TurmaData `json:"turma" binding:"required"`
+	ViewState string    `json:"viewState" binding:"required"`
+	Docente   string    `json:"docente" binding:"required"`
+	Assunto   string    `json:"assunto" binding:"required"`
+	Mensagem  string    `json:"mensagem" binding:"required"`
+}
+
+// @Summary Envia uma mensagem pela caixa postal a um docente da turma
+// @Description O docente é procurado pelo nome completo entre os docentes da turma (sem diferenciar acentos e caixa).
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body EnviarMensagemRequest true "Turma, viewState, nome do docente, assunto e mensagem"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /mensagens/enviar [post]
+// @Security BearerAuth
+func handlePostEnviarMensagem(c *gin.Context) {
+	var req EnviarMensagemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	confirmacao, newJsessionid, newViewState, err := EnviarMensagemDocente(req.Turma, req.Docente, req.Assunto, req.Mensagem, jsessionid, req.ViewState)
+	aviso, err := avisoRetornoPortal(err)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao enviar mensagem: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"confirmacao": confirmacao,
+		"aviso":       aviso,
+		"jsessionid":  newJsessionid,
+		"viewState":   newViewState,
+	})
+}
+
+// ViewStateRequest é o corpo das operações que só precisam do viewState atual
+type ViewStateRequest struct {
+	ViewState string `json:"viewState" binding:"required"`
+}
+
+// @Summary Lista os empréstimos ativos na biblioteca, com prazos e multas
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body ViewStateRequest true "ViewState atual"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /biblioteca/emprestimos [post]
+// @Security BearerAuth
+func handlePostEmprestimos(c *gin.Context) {
+	var req ViewStateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	emprestimos, newJsessionid, newViewState, err := GetEmprestimos(jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar empréstimos: " + err.Error()})
+		return
+	}
+
+	atrasados := 0
+	for _, emprestimo := range emprestimos {
+		if emprestimo.Atrasado {
+			atrasados++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"emprestimos": emprestimos,
+		"atrasados":   atrasados,
+		"jsessionid":  newJsessionid,
+		"viewState":   newViewState,
+	})
+}
+
+type RenovarEmprestimosRequest struct {
+	ViewState string   `json:"viewState" binding:"required"`
+	Ids       []string `json:"ids"`
+	Todos     bool     `json:"todos"` // Renova todos os renováveis; exige ids vazio
+}
+
+// @Summary Renova os empréstimos informados ou, com todos=true, todos os renováveis
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body RenovarEmprestimosRequest true "ViewState e ids (códigos de barras) dos empréstimos ou todos=true"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /biblioteca/renovar [post]
+// @Security BearerAuth
+func handlePostRenovarEmprestimos(c *gin.Context) {
+	var req RenovarEmprestimosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	resultado, newJsessionid, newViewState, err := RenovarEmprestimos(req.Ids, req.Todos, jsessionid, req.ViewState)
+	aviso, err := avisoRetornoPortal(err)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao renovar empréstimos: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"renovados":  resultado.Renovados,
+		"mensagens":  resultado.Mensagens,
+		"aviso":      aviso,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+// @Summary Retorna o estado da matrícula on-line: período e turmas já selecionadas
+// @Description Somente leitura. Fora do período de matrícula, aberta vem falso com a mensagem do SIGAA.
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body ViewStateRequest true "ViewState atual"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /matricula [post]
+// @Security BearerAuth
+func handlePostEstadoMatricula(c *gin.Context) {
+	var req ViewStateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	estado, newJsessionid, newViewState, err := GetEstadoMatricula(jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar matrícula on-line: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"matricula":  estado,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+// @Summary Lista as turmas oferecidas para o currículo do discente na matrícula on-line
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body ViewStateRequest true "ViewState atual"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /matricula/turmas-ofertadas [post]
+// @Security BearerAuth
+func handlePostTurmasOfertadas(c *gin.Context) {
+	var req ViewStateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	turmas, newJsessionid, newViewState, err := GetTurmasOfertadas(jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao buscar turmas ofertadas: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"turmas":     turmas,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+// @Summary Retorna o comprovante de solicitação de matrícula do período
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body ViewStateRequest true "ViewState atual"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /matricula/comprovante [post]
+// @Security BearerAuth
+func handlePostComprovanteMatricula(c *gin.Context) {
+	var req ViewStateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	comprovante, newJsessionid, newViewState, err := GetComprovanteMatricula(jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao buscar comprovante de matrícula: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"comprovante": comprovante,
+		"jsessionid":  newJsessionid,
+		"viewState":   newViewState,
+	})
+}
+
+type SubmeterMatriculaRequest struct {
+	ViewState   string   `json:"viewState" binding:"required"`
+	Turmas      []string `json:"turmas" binding:"required"`
+	DryRun      bool     `json:"dryRun"`
+	Confirmacao string   `json:"confirmacao"`
+}
+
+// @Summary Seleciona turmas na matrícula on-line e confirma a matrícula
+// @Description Com dryRun, as turmas são validadas pelo SIGAA mas a matrícula não é confirmada.
+// @Description A matrícula só é confirmada se todas as turmas pedidas forem adicionadas e o assistente não tiver outras selecionadas.
+// @Description Quando a matrícula não é confirmada, inclusive no dry-run, as turmas adicionadas são removidas do assistente.
+// @Description Fora do dry-run, confirmacao (data de nascimento ou senha) é obrigatória.
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body SubmeterMatriculaRequest true "ViewState, ids das turmas, dryRun e confirmação"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /matricula/submeter [post]
+// @Security BearerAuth
+func handlePostSubmeterMatricula(c *gin.Context) {
+	var req SubmeterMatriculaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	resultado, newJsessionid, newViewState, err := SubmeterMatricula(req.Turmas, req.DryRun, req.Confirmacao, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{
+			"error":  "Erro ao submeter matrícula: " + err.Error(),
+			"turmas": resultado.Turmas,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"resultado":  resultado,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+type ResultadoMatriculaRequest struct {
+	ViewState   string   `json:"viewState" binding:"required"`
+	Solicitadas []string `json:"solicitadas"`
+}
+
+// @Summary Retorna o resultado do processamento de matrícula por turma e a comparação com o que foi pedido
+// @Description solicitadas aceita códigos de componente ou componente-turma (ex: 06232-01). Sem ela, usa o comprovante; fora do período de matrícula, ela é obrigatória.
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body ResultadoMatriculaRequest true "ViewState e, opcionalmente, as turmas solicitadas"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /matricula/resultado [post]
+// @Security BearerAuth
+func handlePostResultadoMatricula(c *gin.Context) {
+	var req ResultadoMatriculaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	resultado, newJsessionid, newViewState, err := GetResultadoProcessamento(req.Solicitadas, jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao buscar resultado da matrícula: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"resultado":  resultado,
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+	})
+}
+
+// @Summary Lista turmas elegíveis para trancamento, o prazo e o andamento das solicitações
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body ViewStateRequest true "ViewState atual"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /trancamento [post]
+// @Security BearerAuth
+func handlePostTrancamento(c *gin.Context) {
+	var req ViewStateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	situacao, newJsessionid, newViewState, err := GetSituacaoTrancamento(jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar trancamento: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"trancamento": situacao,
+		"jsessionid":  newJsessionid,
+		"viewState":   newViewState,
+	})
+}
+
+type SolicitarTrancamentoRequest struct {
+	ViewState     string `json:"viewState" binding:"required"`
+	TurmaId       string `json:"turmaId" binding:"required"`
+	Justificativa string `json:"justificativa" binding:"required"`
+	Confirmar     bool   `json:"confirmar"`
+	Senha         string `json:"senha"`
+}
+
+// @Summary Solicita o trancamento de uma turma
+// @Description Exige confirmar=true: a solicitação não pode ser desfeita pela API. A senha só é usada se o SIGAA pedir.
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body SolicitarTrancamentoRequest true "ViewState, id da turma, justificativa e confirmação"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /trancamento/solicitar [post]
+// @Security BearerAuth
+func handlePostSolicitarTrancamento(c *gin.Context) {
+	var req SolicitarTrancamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+	if !req.Confirmar {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Envie confirmar=true para solicitar o trancamento"})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+	confirmacao, newJsessionid, newViewState, err := SolicitarTrancamento(req.TurmaId, req.Justificativa, req.Senha, jsessionid, req.ViewState)
+	aviso, err := avisoRetornoPortal(err)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao solicitar trancamento: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"confirmacao": confirmacao,
+		"aviso":       aviso,
+		"jsessionid":  newJsessionid,
+		"viewState":   newViewState,
+	})
+}
+
+type NotasRequest struct {
+	ViewState string `json:"viewState" binding:"required"`
+}
+
+// @Summary Baixa o HTML contendo notas
+// @Tags SIGAA
+// @Accept json
+// @Produce json
+// @Param body body NotasRequest true "ViewState atual"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 428 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /notas [post]
+// @Security BearerAuth
+func handlePostNotas(c *gin.Context) {
+	var req NotasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido"})
+		return
+	}
+
+	jsessionid := c.GetString("jsessionid")
+
+	// Chama sua função real
+	notas, newJsessionid, newViewState, err := GetNotas(jsessionid, req.ViewState)
+	if err != nil {
+		c.JSON(statusErroLeitura(err), gin.H{"error": "Erro ao buscar notas: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "HTML de notas baixado com sucesso!",
+		"jsessionid": newJsessionid,
+		"viewState":  newViewState,
+		"notas":      notas,
+	})
+}
+
+// @Summary Busca turmas abertas na consulta pública do SIGAA
+// @Tags Público
+// @Produce json
+// @Param ano query string true "Ano"
+// @Param periodo query string true "Período"
+// @Param nivel query string false "Nível de ensino (padrão G)"
+// @Param componente query string false "Código do componente"
+// @Param departamento query string false "Id ou nome do departamento"
+// @Param docente query string false "Nome do docente"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /turmas-abertas [get]
+func handleGetTurmasAbertas(c *gin.Context) {
+	var filtro FiltroTurmasAbertas
+	if err := c.ShouldBindQuery(&filtro); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros inválidos: " + err.Error()})
+		return
+	}
+	if filtro.Componente == "" && filtro.Departamento == "" && filtro.Docente == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Informe componente, departamento ou docente"})
+		return
+	}
+
+	turmas, err := BuscarTurmasAbertas(filtro)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao buscar turmas abertas: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"turmas": turmas})
+}
+
+// @Summary Busca títulos no acervo da biblioteca
+// @Tags Público
+// @Produce json
+// @Param titulo query string false "Título"
+// @Param autor query string false "Autor"
+// @Param assunto query string false "Assunto"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /biblioteca/acervo [get]
+func handleGetAcervo(c *gin.Context) {
+	var filtro FiltroAcervo
+	if err := c.ShouldBindQuery(&filtro); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros inválidos: " + err.Error()})
+		return
+	}
+	if filtro.Titulo == "" && filtro.Autor == "" && filtro.Assunto == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Informe título, autor ou assunto"})
+		return
+	}
+
+	itens, err := BuscarAcervo(filtro)
+	if err != nil {
+		c.JSON(statusErroOperacao(err), gin.H{"error": "Erro ao buscar no acervo: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"itens": itens})
+}
+
+type ConflitosHorarioRequest struct {
+	Turmas []TurmaCandidata `json:"turmas" binding:"required,min=1,dive"`
+}
+
+// @Summary Verifica choques de horário entre turmas atuais ou candidatas
+// @Tags Público
+// @Accept json
+// @Produce json
+// @Param body body ConflitosHorarioRequest true "Turmas com seus códigos de horário"
+// @Success 200 {object} VerificacaoHorarios
+// @Failure 400 {object} map[string]string
+// @Router /horarios/conflitos [post]
+func handlePostConflitosHorario(c *gin.Context) {
+	var req ConflitosHorarioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JSON inválido: " + err.Error()})
+		return
+	}
+
+	verificacao, err := VerificarHorarios(req.Turmas)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, verificacao)
+}
+
+// avisoRetornoPortal separa a falha de navegação depois de uma escrita já aceita pelo SIGAA: ela vira um
+// aviso na resposta de sucesso, para o cliente não repetir a operação
+func avisoRetornoPortal(err error) (string, error) {
+	if errors.Is(err, ErrRetornoPortal) {
+		return err.Error(), nil
+	}
+	return "", err
+}
+
+// statusErroOperacao traduz o erro de uma operação que altera dados no SIGAA no status HTTP da resposta
+func statusErroOperacao(err error) int {
+	var acao *AcaoNecessariaError
+	switch {
+	// Só a sessão expirada vira 401, para o cliente não pedir um novo login à toa
+	case errors.Is(err, ErrSessaoExpirada):
+		return http.StatusUnauthorized
+	case errors.As(err, &acao):
+		return http.StatusPreconditionRequired
+	case errors.Is(err, ErrRequisicaoInvalida):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrValidacaoSigaa):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrSigaaIndisponivel):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// statusErroLeitura traduz o erro de uma consulta ao SIGAA no status HTTP da resposta, como
+// statusErroOperacao; falhas sem classificação seguem como 500
+func statusErroLeitura(err error) int {
+	if status := statusErroOperacao(err); status != http.StatusBadGateway {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
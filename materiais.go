@@ -0,0 +1,346 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var reVideo = regexp.MustCompile(`(?i)youtube\.com|youtu\.be|vimeo\.com`)
+
+// parseMateriais percorre os tópicos de aula, como parseCronograma, e coleta arquivos, links e vídeos
+func parseMateriais(doc *goquery.Document) []Material {
+	materiais := []Material{}
+	panel := doc.Find("#formAva\\:panelTopicosNaoSelecionados")
+	if panel.Length() == 0 {
+		return materiais
+	}
+
+	// Os spans são aninhados, então o mesmo link aparece mais de uma vez; vale o do tópico mais externo
+	vistos := map[string]bool{}
+	panel.Find("span").Each(func(i int, eventoSpan *goquery.Selection) {
+		eventoDiv := eventoSpan.Children().First()
+		if eventoDiv.Length() == 0 {
+			return
+		}
+		topico := strings.TrimSpace(eventoDiv.Children().Eq(0).Text())
+		conteudoDiv := eventoDiv.Children().Eq(1)
+
+		conteudoDiv.Find("a").Each(func(j int, link *goquery.Selection) {
+			nome := strings.Join(strings.Fields(link.Text()), " ")
+			if nome == "" {
+				nome = link.AttrOr("title", "")
+			}
+			material := Material{Topico: topico, Nome: nome}
+
+			onclick := link.AttrOr("onclick", "")
+			href := link.AttrOr("href", "")
+			switch {
+			case strings.Contains(onclick, "jsfcljs"):
+				material.Tipo = "arquivo"
+				material.params = jsfParams(onclick)
+				material.Id = material.params.Get("id")
+			case strings.HasPrefix(href, "http"):
+				material.Tipo = "link"
+				if reVideo.MatchString(href) {
+					material.Tipo = "video"
+				}
+				material.Url = href
+			default:
+				return
+			}
+
+			key := material.Tipo + material.Url + material.params.Encode()
+			if vistos[key] {
+				return
+			}
+			vistos[key] = true
+
+			if material.Id == "" {
+				material.Id = fmt.Sprintf("%d-%d", i, j)
+			}
+			materiais = append(materiais, material)
+		})
+	})
+
+	return materiais
+}
+
+func GetMateriais(turma TurmaData, jsessionid string, viewState string) ([]Material, string, string, error) {
+	docTurma, jsessionid1, err := acessarTurmaVirtual(turma, jsessionid, viewState)
+	if err != nil {
+		return nil, jsessionid, viewState, err
+	}
+	materiais := parseMateriais(docTurma)
+
+	_, jsessionid2, viewState2, err := getPaginaPortal(jsessionid1)
+	if err != nil {
+		return materiais, jsessionid1, viewState, fmt.Errorf("erro ao voltar para o portal principal: %w", err)
+	}
+	return materiais, jsessionid2, viewState2, nil
+}
+
+// BaixarMaterial entra na turma e dispara o download do arquivo. Quem chama deve fechar o Body.
+// O viewState do portal enviado pelo cliente continua válido depois do download.
+func BaixarMaterial(turma TurmaData, materialId, jsessionid, viewState string) (*http.Response, Material, string, error) {
+	var material Material
+	docTurma, jsessionid1, err := acessarTurmaVirtual(turma, jsessionid, viewState)
+	if err != nil {
+		return nil, material, jsessionid, err
+	}
+	viewStateTurma, err := parseViewState(docTurma, "turma_"+turma.Nome)
+	if err != nil {
+		return nil, material, jsessionid1, err
+	}
+
+	found := false
+	for _, m := range parseMateriais(docTurma) {
+		if m.Id == materialId && m.Tipo == "arquivo" {
+			material, found = m, true
+			break
+		}
+	}
+	if !found {
+		return nil, material, jsessionid1, fmt.Errorf("arquivo %s não encontrado na turma %s", materialId, turma.Nome)
+	}
+
+	resp, newJsessionid, err := baixarArquivoTurma(docTurma, material, jsessionid1, viewStateTurma)
+	return resp, material, newJsessionid, err
+}
+
+// baixarArquivoTurma submete o formAva com os parâmetros do link do arquivo
+func baixarArquivoTurma(docTurma *goquery.Document, material Material, jsessionid, viewState string) (*http.Response, string, error) {
+	form := docTurma.Find("form#formAva")
+	payload := formPayload(form)
+	for key, values := range material.params {
+		payload[key] = values
+	}
+	payload.Set("javax.faces.ViewState", viewState)
+
+	actionUrl := resolveActionUrl(URL_FREQUENCIA, form.AttrOr("action", ""))
+	resp, newJsessionid, err := doSigaaRawRequest(
+		"POST",
+		actionUrl,
+		jsessionid,
+		URL_FREQUENCIA,
+		strings.NewReader(payload.Encode()),
+		"application/x-www-form-urlencoded",
+	)
+	if err != nil {
+		return nil, newJsessionid, fmt.Errorf("erro ao baixar arquivo %s: %w", material.Nome, err)
+	}
+
+	// Se o SIGAA devolveu uma página em vez do arquivo, o download falhou
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		resp.Body.Close()
+		return nil, newJsessionid, fmt.Errorf("o SIGAA não devolveu o arquivo %s", material.Nome)
+	}
+	return resp, newJsessionid, nil
+}
+
+// nomeArquivo usa o nome do Content-Disposition do SIGAA ou, na falta dele, o nome do link
+func nomeArquivo(resp *http.Response, material Material) string {
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+		return params["filename"]
+	}
+	return material.Nome
+}
+
+// Limite padrão (e máximo) do ZIP com os materiais de uma turma
+const MAX_ZIP_MATERIAIS_MB = 200
+
+type ExportacaoMateriais struct {
+	Turma      TurmaData
+	Materiais  []Material
+	Jsessionid string
+	docTurma   *goquery.Document
+	viewState  string
+}
+
+// PrepararExportacaoMateriais entra na turma e lista os materiais antes de começar a escrever o ZIP,
+// para que erros de navegação ainda possam ser respondidos como JSON
+func PrepararExportacaoMateriais(turma TurmaData, jsessionid string, viewState string) (ExportacaoMateriais, error) {
+	exportacao := ExportacaoMateriais{Turma: turma, Jsessionid: jsessionid}
+
+	docTurma, newJsessionid, err := acessarTurmaVirtual(turma, jsessionid, viewState)
+	if err != nil {
+		return exportacao, err
+	}
+	exportacao.Jsessionid = newJsessionid
+
+	viewStateTurma, err := parseViewState(docTurma, "turma_"+turma.Nome)
+	if err != nil {
+		return exportacao, err
+	}
+
+	exportacao.docTurma = docTurma
+	exportacao.viewState = viewStateTurma
+	exportacao.Materiais = parseMateriais(docTurma)
+	return exportacao, nil
+}
+
+var reNomeInvalido = regexp.MustCompile(`[\\/:*?"<>|\x00-\x1f]+`)
+
+func sanitizeNomeArquivo(nome string) string {
+	nome = strings.TrimSpace(reNomeInvalido.ReplaceAllString(nome, "_"))
+	nome = strings.Trim(nome, ". ")
+	if runes := []rune(nome); len(runes) > 100 {
+		nome = string(runes[:100])
+	}
+	return nome
+}
+
+// GerarZipMateriais monta o ZIP num arquivo temporário antes da resposta começar: cada download pode
+// trocar o jsessionid, e o X-Jsessionid só pode ser enviado depois do último. Quem chama fecha e remove o arquivo.
+func GerarZipMateriais(exportacao *ExportacaoMateriais, limiteBytes int64) (*os.File, int64, error) {
+	arquivo, err := os.CreateTemp("", "sigaa-zip-*")
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao criar arquivo temporário: %w", err)
+	}
+	descartar := func() {
+		arquivo.Close()
+		os.Remove(arquivo.Name())
+	}
+
+	if err := EscreverZipMateriais(arquivo, exportacao, limiteBytes); err != nil {
+		descartar()
+		return nil, 0, err
+	}
+	tamanho, err := arquivo.Seek(0, io.SeekCurrent)
+	if err == nil {
+		_, err = arquivo.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		descartar()
+		return nil, 0, err
+	}
+	return arquivo, tamanho, nil
+}
+
+// EscreverZipMateriais baixa cada arquivo para um temporário e o adiciona ao ZIP numa pasta por tópico.
+// Arquivos que estourariam o limite ficam de fora e são registrados no manifest.json.
+// exportacao.Jsessionid acompanha a sessão devolvida por cada download.
+func EscreverZipMateriais(w io.Writer, exportacao *ExportacaoMateriais, limiteBytes int64) error {
+	zw := zip.NewWriter(w)
+	manifesto := ManifestoMateriais{
+		Turma:       exportacao.Turma.Nome,
+		GeradoEm:    time.Now(),
+		LimiteBytes: limiteBytes,
+		Itens:       []ItemManifesto{},
+	}
+
+	pastas := map[string]string{}
+	caminhosUsados := map[string]bool{}
+
+	for _, material := range exportacao.Materiais {
+		item := ItemManifesto{Topico: material.Topico, Nome: material.Nome, Tipo: material.Tipo, Url: material.Url}
+		if material.Tipo != "arquivo" {
+			item.Status = "link"
+			manifesto.Itens = append(manifesto.Itens, item)
+			continue
+		}
+
+		pasta, exists := pastas[material.Topico]
+		if !exists {
+			nomeTopico := sanitizeNomeArquivo(material.Topico)
+			if nomeTopico == "" {
+				nomeTopico = "Sem tópico"
+			}
+			pasta = fmt.Sprintf("%02d - %s", len(pastas)+1, nomeTopico)
+			pastas[material.Topico] = pasta
+		}
+
+		tamanho, caminho, err := adicionarArquivoZip(zw, exportacao, material, pasta, caminhosUsados, limiteBytes-manifesto.TotalBytes)
+		item.Caminho = caminho
+		item.Tamanho = tamanho
+		switch {
+		case errors.Is(err, errLimiteZip):
+			item.Status = "ignorado"
+			item.Erro = err.Error()
+		case err != nil:
+			item.Status = "erro"
+			item.Erro = err.Error()
+		default:
+			item.Status = "ok"
+			manifesto.TotalBytes += tamanho
+		}
+		manifesto.Itens = append(manifesto.Itens, item)
+	}
+
+	manifestoWriter, err := zw.Create("manifest.json")
+	if err != nil {
+		return fmt.Errorf("erro ao criar manifest.json: %w", err)
+	}
+	encoder := json.NewEncoder(manifestoWriter)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifesto); err != nil {
+		return fmt.Errorf("erro ao escrever manifest.json: %w", err)
+	}
+
+	return zw.Close()
+}
+
+var errLimiteZip = errors.New("arquivo ultrapassa o limite de tamanho do ZIP")
+
+func adicionarArquivoZip(zw *zip.Writer, exportacao *ExportacaoMateriais, material Material, pasta string, caminhosUsados map[string]bool, restante int64) (int64, string, error) {
+	resp, newJsessionid, err := baixarArquivoTurma(exportacao.docTurma, material, exportacao.Jsessionid, exportacao.viewState)
+	exportacao.Jsessionid = newJsessionid
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	nome := sanitizeNomeArquivo(nomeArquivo(resp, material))
+	if nome == "" {
+		nome = "arquivo_" + material.Id
+	}
+	caminho := pasta + "/" + nome
+	for n := 2; caminhosUsados[caminho]; n++ {
+		ext := path.Ext(nome)
+		caminho = fmt.Sprintf("%s/%s (%d)%s", pasta, strings.TrimSuffix(nome, ext), n, ext)
+	}
+
+	if resp.ContentLength > restante {
+		return resp.ContentLength, caminho, errLimiteZip
+	}
+
+	tmp, err := os.CreateTemp("", "sigaa-material-*")
+	if err != nil {
+		return 0, caminho, fmt.Errorf("erro ao criar arquivo temporário: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	tamanho, err := io.Copy(tmp, io.LimitReader(resp.Body, restante+1))
+	if err != nil {
+		return tamanho, caminho, fmt.Errorf("erro ao baixar %s: %w", material.Nome, err)
+	}
+	if tamanho > restante {
+		return tamanho, caminho, errLimiteZip
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return tamanho, caminho, err
+	}
+
+	entrada, err := zw.Create(caminho)
+	if err != nil {
+		return tamanho, caminho, fmt.Errorf("erro ao adicionar %s ao ZIP: %w", caminho, err)
+	}
+	if _, err := io.Copy(entrada, tmp); err != nil {
+		return tamanho, caminho, fmt.Errorf("erro ao adicionar %s ao ZIP: %w", caminho, err)
+	}
+
+	caminhosUsados[caminho] = true
+	return tamanho, caminho, nil
+}
@@ -0,0 +1,636 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const ACAO_MATRICULA_ONLINE = "menu_form_menu_discente_discente_menu:A]#{ matriculaGraduacao.telaInstrucoes }"
+
+var rePeriodoLetivo = regexp.MustCompile(`\b(\d{4})\.(\d)\b`)
+
+// abrirMatriculaOnline entra no assistente de matrícula e devolve a página em que ele estiver, sem clicar
+// nos botões do assistente: quem ainda não começou a matrícula fica na tela de instruções.
+// Fora do período, o SIGAA responde com uma mensagem de erro.
+func abrirMatriculaOnline(jsessionid, viewState string) (*goquery.Document, string, string, error) {
+	doc, jsessionid1, viewState1, err := navegarMenuPortal(ACAO_MATRICULA_ONLINE, jsessionid, viewState)
+	if err != nil {
+		if doc != nil {
+			if _, erros := parseMensagensSigaa(doc); len(erros) > 0 {
+				return doc, jsessionid1, viewState, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+			}
+		}
+		return nil, jsessionid1, viewState, fmt.Errorf("erro ao acessar matrícula on-line: %w", err)
+	}
+	if _, erros := parseMensagensSigaa(doc); len(erros) > 0 {
+		return doc, jsessionid1, viewState1, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+	}
+	return doc, jsessionid1, viewState1, nil
+}
+
+// matriculaIniciada diz se o assistente já passou da tela de instruções: a tela de turmas
+// selecionadas traz o link para as turmas do currículo
+func matriculaIniciada(doc *goquery.Document) bool {
+	return strings.Contains(normalizeText(doc.Find("a[onclick]").Text()), "turmas do curriculo")
+}
+
+// iniciarMatriculaOnline passa da tela de instruções para a de turmas selecionadas. Só é usado
+// ao submeter a matrícula, já que avança o assistente do discente.
+func iniciarMatriculaOnline(doc *goquery.Document, jsessionid, viewState string) (*goquery.Document, string, string, error) {
+	if matriculaIniciada(doc) {
+		return doc, jsessionid, viewState, nil
+	}
+	for _, rotulo := range []string{"Iniciar", "Continuar"} {
+		if doc.Find("form").FilterFunction(func(i int, form *goquery.Selection) bool { return findButtonName(form, rotulo) != "" }).Length() == 0 {
+			continue
+		}
+		docTurmas, newJsessionid, newViewState, err := clicarBotao(doc, rotulo, jsessionid, viewState, URL_PORTAL_DISCENTE)
+		if err != nil {
+			return nil, newJsessionid, viewState, fmt.Errorf("erro ao iniciar matrícula on-line: %w", err)
+		}
+		if _, erros := parseMensagensSigaa(docTurmas); len(erros) > 0 {
+			return nil, newJsessionid, newViewState, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+		}
+		return docTurmas, newJsessionid, newViewState, nil
+	}
+	return nil, jsessionid, viewState, fmt.Errorf("tela de turmas selecionadas não encontrada no assistente de matrícula")
+}
+
+// parseTurmasMatricula lê as tabelas de turmas do assistente. Como na consulta pública, o componente
+// vem numa linha agrupadora ("06232 - CÁLCULO NUMÉRICO") e, nas turmas do currículo, o semestre
+// numa linha acima dela.
+func parseTurmasMatricula(doc *goquery.Document) []TurmaMatricula {
+	turmas := []TurmaMatricula{}
+
+	doc.Find("table.listagem, table.listing").Each(func(i int, table *goquery.Selection) {
+		headers := tableHeaders(table)
+		if !strings.Contains(strings.Join(headers, "|"), "horario") {
+			return
+		}
+
+		var semestre, componenteCodigo, componenteNome string
+		table.Find("tbody tr").Each(func(j int, row *goquery.Selection) {
+			cells := row.Find("td")
+			if row.HasClass("agrupador") || cells.Length() == 1 {
+				texto := strings.Join(strings.Fields(cells.First().Text()), " ")
+				if strings.Contains(normalizeText(texto), "semestre") || strings.Contains(normalizeText(texto), "nivel") {
+					semestre = texto
+					return
+				}
+				partes := strings.SplitN(texto, " - ", 2)
+				componenteCodigo = strings.TrimSpace(partes[0])
+				componenteNome = ""
+				if len(partes) > 1 {
+					componenteNome = strings.TrimSpace(partes[1])
+				}
+				return
+			}
+			if cells.Length() < len(headers) {
+				return
+			}
+
+			turma := TurmaMatricula{
+				ComponenteCodigo: componenteCodigo,
+				ComponenteNome:   componenteNome,
+				Semestre:         semestre,
+				Turma:            cellByHeader(headers, cells, "turma"),
+				Docente:          cellByHeader(headers, cells, "docente"),
+				Horarios:         reHorario.FindAllString(cellByHeader(headers, cells, "horario"), -1),
+				Local:            cellByHeader(headers, cells, "local"),
+				Vagas:            parseInt(cellByHeader(headers, cells, "vagas")),
+				Situacao:         cellByHeader(headers, cells, "situacao", "status"),
+			}
+
+			// Na tela de turmas selecionadas, o componente vem numa coluna própria
+			if componente := cellByHeader(headers, cells, "componente", "disciplina"); componente != "" {
+				partes := strings.SplitN(componente, " - ", 2)
+				turma.ComponenteCodigo = strings.TrimSpace(partes[0])
+				if len(partes) > 1 {
+					turma.ComponenteNome = strings.TrimSpace(partes[1])
+				}
+			}
+
+			// Na tela de turmas selecionadas, cada linha tem o link para remover a turma
+			turma.remover = acaoPorRotulo(acoesLinha(row), "remover", "excluir")
+
+			if checkbox := row.Find("input[type='checkbox']").First(); checkbox.Length() > 0 {
+				turma.checkbox = checkbox.AttrOr("name", "")
+				turma.Id = checkbox.AttrOr("value", "")
+			}
+			if turma.Id == "" {
+				if onclick, _ := linkAbrirLinha(row); onclick != "" {
+					turma.Id = jsfParams(onclick).Get("id")
+				}
+			}
+			if turma.Id == "" {
+				turma.Id = fmt.Sprintf("%s-%s", turma.ComponenteCodigo, turma.Turma)
+			}
+
+			turmas = append(turmas, turma)
+		})
+	})
+
+	return turmas
+}
+
+func parsePeriodoMatricula(doc *goquery.Document) string {
+	matches := rePeriodoLetivo.FindStringSubmatch(doc.Find("h2, .descricaoOperacao, caption").Text())
+	if matches == nil {
+		return ""
+	}
+	return matches[1] + "." + matches[2]
+}
+
+// GetEstadoMatricula lê o período e as turmas já selecionadas, sem alterar nada.
+// Fora do período de matrícula, devolve aberta=false com a mensagem do SIGAA.
+func GetEstadoMatricula(jsessionid string, viewState string) (EstadoMatricula, string, string, error) {
+	estado := EstadoMatricula{Selecionadas: []TurmaMatricula{}, Mensagens: []string{}}
+
+	doc, jsessionid1, _, err := abrirMatriculaOnline(jsessionid, viewState)
+	if err != nil && !errors.Is(err, ErrValidacaoSigaa) {
+		return estado, jsessionid1, viewState, err
+	}
+
+	info, erros := parseMensagensSigaa(doc)
+	estado.Mensagens = append(append(estado.Mensagens, erros...), info...)
+	estado.Aberta = err == nil
+	estado.Periodo = parsePeriodoMatricula(doc)
+	if estado.Aberta {
+		estado.Selecionadas = parseTurmasMatricula(doc)
+	}
+
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid1, viewState)
+	return estado, newJsessionid, newViewState, err
+}
+
+// GetTurmasOfertadas lista as turmas oferecidas para o currículo do discente no assistente de matrícula
+func GetTurmasOfertadas(jsessionid string, viewState string) ([]TurmaMatricula, string, string, error) {
+	doc, jsessionid1, viewState1, err := abrirMatriculaOnline(jsessionid, viewState)
+	if err != nil {
+		return nil, jsessionid1, viewState, err
+	}
+	if !matriculaIniciada(doc) {
+		return nil, jsessionid1, viewState, fmt.Errorf("%w: a matrícula on-line ainda não foi iniciada no SIGAA", ErrValidacaoSigaa)
+	}
+
+	docCurriculo, jsessionid2, _, err := clicarLinkPorTexto(doc, "Turmas do Currículo", jsessionid1, viewState1, URL_PORTAL_DISCENTE)
+	if err != nil {
+		return nil, jsessionid1, viewState, fmt.Errorf("erro ao abrir turmas do currículo: %w", err)
+	}
+
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid2, viewState)
+	return parseTurmasMatricula(docCurriculo), newJsessionid, newViewState, err
+}
+
+func parseComprovanteMatricula(doc *goquery.Document) ComprovanteMatricula {
+	comprovante := ComprovanteMatricula{
+		Periodo: parsePeriodoMatricula(doc),
+		Turmas:  parseTurmasMatricula(doc),
+	}
+	for _, linha := range textLines(doc.Find("body")) {
+		if strings.Contains(normalizeText(linha), "emiti") {
+			comprovante.Emissao = reDataHora.FindString(linha)
+			break
+		}
+	}
+	return comprovante
+}
+
+// GetComprovanteMatricula abre o comprovante das solicitações de matrícula do período
+func GetComprovanteMatricula(jsessionid string, viewState string) (ComprovanteMatricula, string, string, error) {
+	doc, jsessionid1, viewState1, err := abrirMatriculaOnline(jsessionid, viewState)
+	if err != nil {
+		return ComprovanteMatricula{}, jsessionid1, viewState, err
+	}
+
+	docComprovante, jsessionid2, _, err := clicarLinkPorTexto(doc, "Comprovante", jsessionid1, viewState1, URL_PORTAL_DISCENTE)
+	if err != nil {
+		return ComprovanteMatricula{}, jsessionid1, viewState, fmt.Errorf("erro ao abrir comprovante de matrícula: %w", err)
+	}
+
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid2, viewState)
+	return parseComprovanteMatricula(docComprovante), newJsessionid, newViewState, err
+}
+
+const (
+	MATRICULA_SELECIONADA    = "selecionada"
+	MATRICULA_JA_SELECIONADA = "ja_selecionada"
+	MATRICULA_RECUSADA       = "recusada"
+	MATRICULA_NAO_ENCONTRADA = "nao_encontrada"
+)
+
+// abrirTurmasCurriculo garante que a página atual seja a de turmas do currículo: depois de adicionar
+// uma turma o SIGAA volta para as turmas selecionadas, mas quando recusa a seleção permanece nela
+func abrirTurmasCurriculo(doc *goquery.Document, jsessionid, viewState string) (*goquery.Document, string, string, error) {
+	if doc.Find("table.listagem input[type='checkbox'], table.listing input[type='checkbox']").Length() > 0 && findButtonName(doc.Selection, "Adicionar") != "" {
+		return doc, jsessionid, viewState, nil
+	}
+	docCurriculo, newJsessionid, newViewState, err := clicarLinkPorTexto(doc, "Turmas do Currículo", jsessionid, viewState, URL_PORTAL_DISCENTE)
+	if err != nil {
+		return nil, jsessionid, viewState, fmt.Errorf("erro ao abrir turmas do currículo: %w", err)
+	}
+	return docCurriculo, newJsessionid, newViewState, nil
+}
+
+// adicionarTurmaMatricula marca uma única turma e clica em "Adicionar", para que as mensagens
+// do SIGAA (choque de horário, vagas, pré-requisitos) possam ser atribuídas a ela
+func adicionarTurmaMatricula(docCurriculo *goquery.Document, turma TurmaMatricula, jsessionid, viewState string) (*goquery.Document, string, string, []string, []string, error) {
+	form := docCurriculo.Find("input[name='" + turma.checkbox + "']").First().Closest("form")
+	botao := findButtonName(form, "Adicionar")
+	if botao == "" {
+		return nil, jsessionid, viewState, nil, nil, fmt.Errorf("botão 'Adicionar' não encontrado nas turmas do currículo")
+	}
+
+	payload := formPayload(form)
+	payload.Set("javax.faces.ViewState", viewState)
+	payload.Set(turma.checkbox, turma.Id)
+	payload.Set(botao, form.Find("[name='"+botao+"']").AttrOr("value", "Adicionar"))
+
+	docResultado, newJsessionid, err := postSigaaForm(resolveActionUrl(URL_PORTAL_DISCENTE, form.AttrOr("action", "")), jsessionid, URL_PORTAL_DISCENTE, payload)
+	if err != nil {
+		return nil, jsessionid, viewState, nil, nil, fmt.Errorf("erro ao adicionar turma %s: %w", turma.Turma, err)
+	}
+	newViewState, err := parseViewState(docResultado, "adicionar turma")
+	if err != nil {
+		return nil, newJsessionid, viewState, nil, nil, err
+	}
+	info, erros := parseMensagensSigaa(docResultado)
+	return docResultado, newJsessionid, newViewState, info, erros, nil
+}
+
+// abrirTurmasSelecionadas volta para a tela de turmas selecionadas, de onde partem a confirmação
+// e a remoção de turmas
+func abrirTurmasSelecionadas(doc *goquery.Document, jsessionid, viewState string) (*goquery.Document, string, string, error) {
+	if findButtonName(doc.Selection, "Confirmar") != "" {
+		return doc, jsessionid, viewState, nil
+	}
+	docSelecionadas, newJsessionid, newViewState, err := clicarLinkPorTexto(doc, "Turmas Selecionadas", jsessionid, viewState, URL_PORTAL_DISCENTE)
+	if err != nil {
+		return nil, jsessionid, viewState, fmt.Errorf("erro ao voltar para as turmas selecionadas: %w", err)
+	}
+	return docSelecionadas, newJsessionid, newViewState, nil
+}
+
+// removerTurmasMatricula desfaz a seleção das turmas informadas, uma a uma, pelo link de remoção
+// de cada linha das turmas selecionadas
+func removerTurmasMatricula(doc *goquery.Document, ids []string, jsessionid, viewState string) (string, string, error) {
+	docAtual, jsessionidAtual, viewStateAtual, err := abrirTurmasSelecionadas(doc, jsessionid, viewState)
+	if err != nil {
+		return jsessionidAtual, viewStateAtual, err
+	}
+	for _, id := range ids {
+		var remover string
+		for _, turma := range parseTurmasMatricula(docAtual) {
+			if turma.Id == id {
+				remover = turma.remover
+				break
+			}
+		}
+		if remover == "" {
+			return jsessionidAtual, viewStateAtual, fmt.Errorf("link para remover a turma %s não encontrado nas turmas selecionadas", id)
+		}
+
+		docResultado, jsessionidResultado, viewStateResultado, err := clicarLinkJsf(docAtual, jsfFormId(remover), jsfParams(remover), jsessionidAtual, viewStateAtual, URL_PORTAL_DISCENTE)
+		if err != nil {
+			return jsessionidResultado, viewStateAtual, fmt.Errorf("erro ao remover turma %s: %w", id, err)
+		}
+		if _, erros := parseMensagensSigaa(docResultado); len(erros) > 0 {
+			return jsessionidResultado, viewStateResultado, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+		}
+		docAtual, jsessionidAtual, viewStateAtual, err = abrirTurmasSelecionadas(docResultado, jsessionidResultado, viewStateResultado)
+		if err != nil {
+			return jsessionidAtual, viewStateAtual, err
+		}
+	}
+	return jsessionidAtual, viewStateAtual, nil
+}
+
+// confirmarMatricula clica em "Confirmar Matrícula" e responde à confirmação de identidade
+// (data de nascimento ou senha, conforme o SIGAA pedir)
+func confirmarMatricula(doc *goquery.Document, confirmacao, jsessionid, viewState string) ([]string, string, error) {
+	docConfirmacao, jsessionid1, viewState1, err := clicarBotao(doc, "Confirmar", jsessionid, viewState, URL_PORTAL_DISCENTE)
+	if err != nil {
+		return nil, jsessionid, fmt.Errorf("erro ao iniciar confirmação da matrícula: %w", err)
+	}
+	if _, erros := parseMensagensSigaa(docConfirmacao); len(erros) > 0 {
+		return nil, jsessionid1, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+	}
+
+	campo := findFieldName(docConfirmacao.Selection, "nascimento", "senha")
+	if campo == "" {
+		return nil, jsessionid1, fmt.Errorf("campo de confirmação da matrícula não encontrado")
+	}
+	form := docConfirmacao.Find("[name='" + campo + "']").First().Closest("form")
+	botao := findButtonName(form, "Confirmar")
+	if botao == "" {
+		return nil, jsessionid1, fmt.Errorf("botão 'Confirmar' não encontrado na confirmação da matrícula")
+	}
+
+	payload := formPayload(form)
+	payload.Set("javax.faces.ViewState", viewState1)
+	payload.Set(campo, confirmacao)
+	payload.Set(botao, form.Find("[name='"+botao+"']").AttrOr("value", "Confirmar"))
+
+	docResultado, jsessionid2, err := postSigaaForm(resolveActionUrl(URL_PORTAL_DISCENTE, form.AttrOr("action", "")), jsessionid1, URL_PORTAL_DISCENTE, payload)
+	if err != nil {
+		return nil, jsessionid1, fmt.Errorf("erro ao confirmar matrícula: %w", err)
+	}
+	info, erros := parseMensagensSigaa(docResultado)
+	if len(erros) > 0 {
+		return nil, jsessionid2, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+	}
+	return info, jsessionid2, nil
+}
+
+// SubmeterMatricula seleciona as turmas pedidas uma a uma e, fora do dry-run, confirma a matrícula.
+// A confirmação só acontece se todas as turmas pedidas forem adicionadas agora e o assistente não
+// tiver outras selecionadas; senão, como no dry-run, as turmas adicionadas são removidas de novo.
+func SubmeterMatricula(ids []string, dryRun bool, confirmacao string, jsessionid string, viewState string) (ResultadoMatricula, string, string, error) {
+	resultado := ResultadoMatricula{DryRun: dryRun, Turmas: []ResultadoTurmaMatricula{}, Mensagens: []string{}}
+	if len(ids) == 0 {
+		return resultado, jsessionid, viewState, fmt.Errorf("%w: informe ao menos uma turma", ErrRequisicaoInvalida)
+	}
+	if !dryRun && confirmacao == "" {
+		return resultado, jsessionid, viewState, fmt.Errorf("%w: a confirmação (data de nascimento ou senha) é obrigatória fora do dry-run", ErrRequisicaoInvalida)
+	}
+
+	docInstrucoes, jsessionid0, viewState0, err := abrirMatriculaOnline(jsessionid, viewState)
+	if err != nil {
+		return resultado, jsessionid0, viewState, err
+	}
+	doc, jsessionid1, viewState1, err := iniciarMatriculaOnline(docInstrucoes, jsessionid0, viewState0)
+	if err != nil {
+		return resultado, jsessionid1, viewState, err
+	}
+
+	selecionadas := map[string]bool{}
+	for _, turma := range parseTurmasMatricula(doc) {
+		selecionadas[turma.Id] = true
+	}
+
+	docAtual, jsessionidAtual, viewStateAtual := doc, jsessionid1, viewState1
+	for _, id := range ids {
+		item := ResultadoTurmaMatricula{Id: id, Mensagens: []string{}}
+		if selecionadas[id] {
+			item.Status = MATRICULA_JA_SELECIONADA
+			resultado.Turmas = append(resultado.Turmas, item)
+			continue
+		}
+
+		docCurriculo, jsessionidCurriculo, viewStateCurriculo, err := abrirTurmasCurriculo(docAtual, jsessionidAtual, viewStateAtual)
+		if err != nil {
+			return resultado, jsessionidAtual, viewState, err
+		}
+		docAtual, jsessionidAtual, viewStateAtual = docCurriculo, jsessionidCurriculo, viewStateCurriculo
+
+		var turma TurmaMatricula
+		for _, t := range parseTurmasMatricula(docCurriculo) {
+			if t.Id == id && t.checkbox != "" {
+				turma = t
+				break
+			}
+		}
+		if turma.Id == "" {
+			item.Status = MATRICULA_NAO_ENCONTRADA
+			resultado.Turmas = append(resultado.Turmas, item)
+			continue
+		}
+		item.ComponenteCodigo, item.ComponenteNome, item.Turma = turma.ComponenteCodigo, turma.ComponenteNome, turma.Turma
+
+		docResultado, jsessionidResultado, viewStateResultado, info, erros, err := adicionarTurmaMatricula(docCurriculo, turma, jsessionidAtual, viewStateAtual)
+		if err != nil {
+			return resultado, jsessionidResultado, viewState, err
+		}
+		docAtual, jsessionidAtual, viewStateAtual = docResultado, jsessionidResultado, viewStateResultado
+
+		if len(erros) > 0 {
+			item.Status = MATRICULA_RECUSADA
+			item.Mensagens = append(item.Mensagens, erros...)
+		} else {
+			item.Status = MATRICULA_SELECIONADA
+			item.Mensagens = append(item.Mensagens, info...)
+			selecionadas[id] = true
+		}
+		resultado.Turmas = append(resultado.Turmas, item)
+	}
+
+	adicionadas := []string{}
+	for _, item := range resultado.Turmas {
+		if item.Status == MATRICULA_SELECIONADA {
+			adicionadas = append(adicionadas, item.Id)
+		}
+	}
+	foraDoPedido := []string{}
+	for id := range selecionadas {
+		if !slices.Contains(ids, id) {
+			foraDoPedido = append(foraDoPedido, id)
+		}
+	}
+	slices.Sort(foraDoPedido)
+
+	confirmar := !dryRun
+	if confirmar && len(adicionadas) < len(resultado.Turmas) {
+		resultado.Mensagens = append(resultado.Mensagens, "Nem todas as turmas pedidas foram adicionadas agora; a matrícula não foi confirmada.")
+		confirmar = false
+	}
+	if confirmar && len(foraDoPedido) > 0 {
+		resultado.Mensagens = append(resultado.Mensagens, fmt.Sprintf("O assistente tem turmas selecionadas fora do pedido (%s); a matrícula não foi confirmada.", strings.Join(foraDoPedido, ", ")))
+		confirmar = false
+	}
+
+	if confirmar {
+		docSelecionadas, jsessionidSelecionadas, viewStateSelecionadas, err := abrirTurmasSelecionadas(docAtual, jsessionidAtual, viewStateAtual)
+		if err != nil {
+			return resultado, jsessionidSelecionadas, viewState, err
+		}
+		info, jsessionidConfirmacao, err := confirmarMatricula(docSelecionadas, confirmacao, jsessionidSelecionadas, viewStateSelecionadas)
+		if err != nil {
+			return resultado, jsessionidConfirmacao, viewState, err
+		}
+		jsessionidAtual = jsessionidConfirmacao
+		resultado.Confirmada = true
+		resultado.Mensagens = append(resultado.Mensagens, info...)
+	} else if len(adicionadas) > 0 {
+		jsessionidRemocao, _, err := removerTurmasMatricula(docAtual, adicionadas, jsessionidAtual, viewStateAtual)
+		if err != nil {
+			return resultado, jsessionidRemocao, viewState, fmt.Errorf("as turmas adicionadas continuam selecionadas no assistente: %w", err)
+		}
+		jsessionidAtual = jsessionidRemocao
+	}
+
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionidAtual, viewState)
+	return resultado, newJsessionid, newViewState, err
+}
+
+const ACAO_RESULTADO_MATRICULA = "menu_form_menu_discente_discente_menu:A]#{ matriculaGraduacao.exibirResultadoProcessamento }"
+
+const (
+	PROCESSAMENTO_ACEITA     = "aceita"
+	PROCESSAMENTO_RECUSADA   = "recusada"
+	PROCESSAMENTO_AGUARDANDO = "aguardando"
+)
+
+func statusProcessamento(situacao string) string {
+	situacao = normalizeText(situacao)
+	switch {
+	case strings.Contains(situacao, "matriculad"), strings.Contains(situacao, "deferid") && !strings.Contains(situacao, "indeferid"):
+		return PROCESSAMENTO_ACEITA
+	case strings.Contains(situacao, "indeferid"), strings.Contains(situacao, "negad"), strings.Contains(situacao, "exclu"),
+		strings.Contains(situacao, "cancelad"), strings.Contains(situacao, "recusad"):
+		return PROCESSAMENTO_RECUSADA
+	default:
+		return PROCESSAMENTO_AGUARDANDO
+	}
+}
+
+// parseResultadoProcessamento lê o resultado por turma. Matrícula e rematrícula aparecem como fases
+// separadas, em linhas agrupadoras ou na legenda de cada tabela.
+func parseResultadoProcessamento(doc *goquery.Document) []TurmaProcessada {
+	turmas := []TurmaProcessada{}
+
+	doc.Find("table.listagem, table.listing").Each(func(i int, table *goquery.Selection) {
+		headers := tableHeaders(table)
+		if !strings.Contains(strings.Join(headers, "|"), "situacao") {
+			return
+		}
+
+		fase := strings.Join(strings.Fields(table.Find("caption").First().Text()), " ")
+		table.Find("tbody tr").Each(func(j int, row *goquery.Selection) {
+			cells := row.Find("td")
+			if row.HasClass("agrupador") || cells.Length() == 1 {
+				fase = strings.Join(strings.Fields(cells.First().Text()), " ")
+				return
+			}
+			if cells.Length() < len(headers) {
+				return
+			}
+
+			turma := TurmaProcessada{
+				Turma:    cellByHeader(headers, cells, "turma"),
+				Fase:     fase,
+				Situacao: cellByHeader(headers, cells, "situacao"),
+				Motivo:   cellByHeader(headers, cells, "motivo", "observac", "justificativa"),
+			}
+			partes := strings.SplitN(cellByHeader(headers, cells, "componente", "disciplina"), " - ", 2)
+			turma.ComponenteCodigo = strings.TrimSpace(partes[0])
+			if len(partes) > 1 {
+				turma.ComponenteNome = strings.TrimSpace(partes[1])
+			}
+			if turma.ComponenteCodigo == "" {
+				return
+			}
+			turma.Status = statusProcessamento(turma.Situacao)
+			turmas = append(turmas, turma)
+		})
+	})
+
+	return turmas
+}
+
+func chaveTurma(componenteCodigo, turma string) string {
+	return strings.ToUpper(strings.TrimSpace(componenteCodigo)) + "-" + strings.ToUpper(strings.TrimSpace(turma))
+}
+
+// diffMatricula compara o que foi pedido com o resultado. Um pedido pode ser só o código do componente
+// ou componente-turma; vale a situação da última fase processada.
+func diffMatricula(solicitadas []string, turmas []TurmaProcessada) DiffMatricula {
+	diff := DiffMatricula{
+		Aceitas:        []TurmaProcessada{},
+		Recusadas:      []TurmaProcessada{},
+		Aguardando:     []TurmaProcessada{},
+		SemResultado:   []string{},
+		NaoSolicitadas: []TurmaProcessada{},
+	}
+
+	ultimaPorChave := map[string]TurmaProcessada{}
+	var chaves []string
+	for _, turma := range turmas {
+		chave := chaveTurma(turma.ComponenteCodigo, turma.Turma)
+		if _, exists := ultimaPorChave[chave]; !exists {
+			chaves = append(chaves, chave)
+		}
+		ultimaPorChave[chave] = turma
+	}
+
+	atendidas := map[string]bool{}
+	for _, pedido := range solicitadas {
+		pedido = strings.ToUpper(strings.TrimSpace(pedido))
+		encontrada := false
+		for _, chave := range chaves {
+			turma := ultimaPorChave[chave]
+			if chave != pedido && strings.ToUpper(turma.ComponenteCodigo) != pedido {
+				continue
+			}
+			encontrada = true
+			if atendidas[chave] {
+				continue
+			}
+			atendidas[chave] = true
+			switch turma.Status {
+			case PROCESSAMENTO_ACEITA:
+				diff.Aceitas = append(diff.Aceitas, turma)
+			case PROCESSAMENTO_RECUSADA:
+				diff.Recusadas = append(diff.Recusadas, turma)
+			default:
+				diff.Aguardando = append(diff.Aguardando, turma)
+			}
+		}
+		if !encontrada {
+			diff.SemResultado = append(diff.SemResultado, pedido)
+		}
+	}
+
+	for _, chave := range chaves {
+		if !atendidas[chave] {
+			diff.NaoSolicitadas = append(diff.NaoSolicitadas, ultimaPorChave[chave])
+		}
+	}
+	return diff
+}
+
+// GetResultadoProcessamento lê o resultado do processamento e o compara com as turmas pedidas.
+// Sem lista informada, usa as solicitações do comprovante, que só existe enquanto o assistente de matrícula
+// estiver aberto; sem ele, as turmas solicitadas precisam ser informadas.
+func GetResultadoProcessamento(solicitadas []string, jsessionid string, viewState string) (ResultadoProcessamento, string, string, error) {
+	resultado := ResultadoProcessamento{Turmas: []TurmaProcessada{}}
+
+	doc, jsessionid1, _, err := navegarMenuPortal(ACAO_RESULTADO_MATRICULA, jsessionid, viewState)
+	if err != nil {
+		return resultado, jsessionid1, viewState, fmt.Errorf("erro ao acessar resultado do processamento de matrícula: %w", err)
+	}
+	if _, erros := parseMensagensSigaa(doc); len(erros) > 0 {
+		return resultado, jsessionid1, viewState, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+	}
+	resultado.Periodo = parsePeriodoMatricula(doc)
+	resultado.Turmas = parseResultadoProcessamento(doc)
+
+	jsessionidAtual, viewStateAtual, err := voltarAoPortal(jsessionid1, viewState)
+	if err != nil {
+		return resultado, jsessionidAtual, viewStateAtual, err
+	}
+
+	if len(solicitadas) == 0 {
+		comprovante, jsessionidComprovante, viewStateComprovante, err := GetComprovanteMatricula(jsessionidAtual, viewStateAtual)
+		if err == nil {
+			jsessionidAtual, viewStateAtual = jsessionidComprovante, viewStateComprovante
+			for _, turma := range comprovante.Turmas {
+				solicitadas = append(solicitadas, chaveTurma(turma.ComponenteCodigo, turma.Turma))
+			}
+		} else if jsessionidVolta, viewStateVolta, err := voltarAoPortal(jsessionidComprovante, viewStateAtual); err == nil {
+			// Assistente fechado: sem comprovante, a requisição é recusada abaixo
+			jsessionidAtual, viewStateAtual = jsessionidVolta, viewStateVolta
+		}
+	}
+	if len(solicitadas) == 0 {
+		// Comparar o resultado com ele mesmo daria sempre um diff vazio
+		return resultado, jsessionidAtual, viewStateAtual, fmt.Errorf("%w: o comprovante de matrícula não está disponível; informe as turmas solicitadas", ErrRequisicaoInvalida)
+	}
+
+	resultado.Diff = diffMatricula(solicitadas, resultado.Turmas)
+	return resultado, jsessionidAtual, viewStateAtual, nil
+}
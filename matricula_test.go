@@ -0,0 +1,101 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffMatricula(t *testing.T) {
+	calculo01 := TurmaProcessada{ComponenteCodigo: "06232", Turma: "01", Fase: "Matrícula", Status: PROCESSAMENTO_ACEITA}
+	calculo02 := TurmaProcessada{ComponenteCodigo: "06232", Turma: "02", Fase: "Matrícula", Status: PROCESSAMENTO_RECUSADA}
+	fisicaMatricula := TurmaProcessada{ComponenteCodigo: "06110", Turma: "01", Fase: "Matrícula", Status: PROCESSAMENTO_RECUSADA}
+	fisicaRematricula := TurmaProcessada{ComponenteCodigo: "06110", Turma: "01", Fase: "Rematrícula", Status: PROCESSAMENTO_ACEITA}
+	quimica := TurmaProcessada{ComponenteCodigo: "06300", Turma: "01", Fase: "Matrícula", Status: PROCESSAMENTO_AGUARDANDO}
+
+	tests := []struct {
+		nome           string
+		solicitadas    []string
+		turmas         []TurmaProcessada
+		aceitas        []string
+		recusadas      []string
+		aguardando     []string
+		semResultado   []string
+		naoSolicitadas []string
+	}{
+		{
+			nome:        "componente-turma",
+			solicitadas: []string{"06232-01", "06300-01"},
+			turmas:      []TurmaProcessada{calculo01, quimica},
+			aceitas:     []string{"06232-01"},
+			aguardando:  []string{"06300-01"},
+		},
+		{
+			nome:        "só o componente pega todas as turmas dele",
+			solicitadas: []string{"06232"},
+			turmas:      []TurmaProcessada{calculo01, calculo02},
+			aceitas:     []string{"06232-01"},
+			recusadas:   []string{"06232-02"},
+		},
+		{
+			nome:        "vale a última fase",
+			solicitadas: []string{"06110-01"},
+			turmas:      []TurmaProcessada{fisicaMatricula, fisicaRematricula},
+			aceitas:     []string{"06110-01"},
+		},
+		{
+			nome:         "pedido sem resultado, normalizado",
+			solicitadas:  []string{" 06232-01 ", "99999"},
+			turmas:       []TurmaProcessada{calculo01},
+			aceitas:      []string{"06232-01"},
+			semResultado: []string{"99999"},
+		},
+		{
+			nome:           "turma não solicitada",
+			solicitadas:    []string{"06232-01"},
+			turmas:         []TurmaProcessada{calculo01, quimica},
+			aceitas:        []string{"06232-01"},
+			naoSolicitadas: []string{"06300-01"},
+		},
+		{
+			nome:        "pedido repetido conta uma vez",
+			solicitadas: []string{"06232", "06232-01"},
+			turmas:      []TurmaProcessada{calculo01},
+			aceitas:     []string{"06232-01"},
+		},
+	}
+
+	chaves := func(turmas []TurmaProcessada) []string {
+		resultado := []string{}
+		for _, turma := range turmas {
+			resultado = append(resultado, chaveTurma(turma.ComponenteCodigo, turma.Turma))
+		}
+		return resultado
+	}
+	vazioSeNil := func(s []string) []string {
+		if s == nil {
+			return []string{}
+		}
+		return s
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			diff := diffMatricula(tt.solicitadas, tt.turmas)
+			for _, grupo := range []struct {
+				nome     string
+				obtido   []string
+				esperado []string
+			}{
+				{"aceitas", chaves(diff.Aceitas), tt.aceitas},
+				{"recusadas", chaves(diff.Recusadas), tt.recusadas},
+				{"aguardando", chaves(diff.Aguardando), tt.aguardando},
+				{"semResultado", diff.SemResultado, tt.semResultado},
+				{"naoSolicitadas", chaves(diff.NaoSolicitadas), tt.naoSolicitadas},
+			} {
+				if !slices.Equal(grupo.obtido, vazioSeNil(grupo.esperado)) {
+					t.Errorf("%s = %v, esperava %v", grupo.nome, grupo.obtido, vazioSeNil(grupo.esperado))
+				}
+			}
+		})
+	}
+}
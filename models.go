@@ -1,23 +1,33 @@
 package main
 
-import "errors"
+import (
+	"errors"
+	"net/url"
+	"time"
+)
 
 var ErrInvalidCredentials = errors.New("usuário ou senha inválidos")
+var ErrValidacaoSigaa = errors.New("o SIGAA recusou a operação")
+var ErrRequisicaoInvalida = errors.New("requisição inválida")
+var ErrSigaaIndisponivel = errors.New("o SIGAA está indisponível")
+var ErrSessaoExpirada = errors.New("sessão expirada ou inválida")
+var ErrRetornoPortal = errors.New("a operação foi concluída no SIGAA, mas não foi possível voltar ao portal")
 
 const (
 	FALTAS_INDEFINIDAS   = -2
 	PRESENCA_NAO_LANCADA = -1
 )
 
+// IndicesAcademicos traz os índices do portal; os que o portal não mostra ficam nulos
 type IndicesAcademicos struct {
-	MC    string `json:"mc"`
-	IRA   string `json:"ira"`
-	MCN   string `json:"mcn"`
-	IECH  string `json:"iech"`
-	IEPL  string `json:"iepl"`
-	IEA   string `json:"iea"`
-	IEAN  string `json:"iean"`
-	IECHP string `json:"iechp"`
+	MC    *float64 `json:"mc"`
+	IRA   *float64 `json:"ira"`
+	MCN   *float64 `json:"mcn"`
+	IECH  *float64 `json:"iech"`
+	IEPL  *float64 `json:"iepl"`
+	IEA   *float64 `json:"iea"`
+	IEAN  *float64 `json:"iean"`
+	IECHP *float64 `json:"iechp"`
 }
 
 type CargasHorarias struct {
@@ -79,3 +89,477 @@ type LoginResponse struct {
 	Nome   string      `json:"nome"`
 	Turmas []TurmaData `json:"turmas"`
 }
+
+type FiltroTurmasAbertas struct {
+	Ano          string `form:"ano" binding:"required"`
+	Periodo      string `form:"periodo" binding:"required"`
+	Nivel        string `form:"nivel"`
+	Componente   string `form:"componente"`
+	Departamento string `form:"departamento"`
+	Docente      string `form:"docente"`
+}
+
+type TurmaAberta struct {
+	ComponenteCodigo string   `json:"componenteCodigo"`
+	ComponenteNome   string   `json:"componenteNome"`
+	Codigo           string   `json:"codigo"`
+	Periodo          string   `json:"periodo"`
+	Docente          string   `json:"docente"`
+	Horarios         []string `json:"horarios"`
+	Local            string   `json:"local"`
+	Capacidade       int      `json:"capacidade"`
+	Matriculados     int      `json:"matriculados"`
+}
+
+type HorarioSlot struct {
+	Dia     int    `json:"dia"`
+	DiaNome string `json:"diaNome"`
+	Turno   string `json:"turno"`
+	Aula    int    `json:"aula"`
+	Inicio  string `json:"inicio"`
+	Fim     string `json:"fim"`
+}
+
+type TurmaCandidata struct {
+	Componente string   `json:"componente" binding:"required"`
+	Turma      string   `json:"turma"`
+	Horarios   []string `json:"horarios" binding:"required"`
+}
+
+type ConflitoHorario struct {
+	Slot   HorarioSlot `json:"slot"`
+	Turmas []string    `json:"turmas"`
+}
+
+type CombinacaoTurmas struct {
+	Turmas              []TurmaCandidata `json:"turmas"`
+	CargaHorariaSemanal int              `json:"cargaHorariaSemanal"`
+}
+
+type VerificacaoHorarios struct {
+	Conflitos []ConflitoHorario `json:"conflitos"`
+	// Só vem quando há uma turma por componente; com alternativas, cada combinação traz a sua
+	CargaHorariaSemanal *int               `json:"cargaHorariaSemanal,omitempty"`
+	Combinacoes         []CombinacaoTurmas `json:"combinacoes"`
+	Truncado            bool               `json:"truncado"` // Havia mais combinações do que MAX_COMBINACOES
+}
+
+type Participante struct {
+	Nome      string `json:"nome"`
+	Tipo      string `json:"tipo"`
+	Curso     string `json:"curso"`
+	Matricula string `json:"matricula"`
+	Email     string `json:"email"`
+	FotoUrl   string `json:"fotoUrl"`
+}
+
+type Material struct {
+	Id     string `json:"id"`
+	Topico string `json:"topico"`
+	Tipo   string `json:"tipo"`
+	Nome   string `json:"nome"`
+	Url    string `json:"url"`
+	params url.Values
+}
+
+type ItemManifesto struct {
+	Topico  string `json:"topico"`
+	Nome    string `json:"nome"`
+	Tipo    string `json:"tipo"`
+	Caminho string `json:"caminho"`
+	Url     string `json:"url"`
+	Tamanho int64  `json:"tamanho"`
+	Status  string `json:"status"`
+	Erro    string `json:"erro"`
+}
+
+type ManifestoMateriais struct {
+	Turma       string          `json:"turma"`
+	GeradoEm    time.Time       `json:"geradoEm"`
+	LimiteBytes int64           `json:"limiteBytes"`
+	TotalBytes  int64           `json:"totalBytes"`
+	Itens       []ItemManifesto `json:"itens"`
+}
+
+type Tarefa struct {
+	Id          string `json:"id"`
+	Titulo      string `json:"titulo"`
+	Descricao   string `json:"descricao"`
+	Inicio      string `json:"inicio"`
+	Fim         string `json:"fim"`
+	Status      string `json:"status"`
+	Feedback    string `json:"feedback"`
+	Nota        string `json:"nota"`
+	formEnvio   string
+	paramsEnvio url.Values
+}
+
+type TrechoTexto struct {
+	Tipo  string `json:"tipo"`
+	Texto string `json:"texto"`
+	Url   string `json:"url"`
+}
+
+type Paragrafo struct {
+	Trechos []TrechoTexto `json:"trechos"`
+}
+
+type NoticiaResumo struct {
+	Id               string `json:"id"`
+	Titulo           string `json:"titulo"`
+	Data             string `json:"data"`
+	Autor            string `json:"autor"`
+	formVisualizar   string
+	paramsVisualizar url.Values
+}
+
+type NoticiaDetalhada struct {
+	Id       string      `json:"id"`
+	Titulo   string      `json:"titulo"`
+	Data     string      `json:"data"`
+	Autor    string      `json:"autor"`
+	Conteudo []Paragrafo `json:"conteudo"`
+}
+
+type Forum struct {
+	Id             string `json:"id"`
+	Titulo         string `json:"titulo"`
+	Autor          string `json:"autor"`
+	Topicos        int    `json:"topicos"`
+	UltimaMensagem string `json:"ultimaMensagem"`
+	formAbrir      string
+	paramsAbrir    url.Values
+}
+
+type TopicoForum struct {
+	Id             string `json:"id"`
+	Titulo         string `json:"titulo"`
+	Autor          string `json:"autor"`
+	Respostas      int    `json:"respostas"`
+	UltimaMensagem string `json:"ultimaMensagem"`
+	formAbrir      string
+	paramsAbrir    url.Values
+}
+
+type MensagemForum struct {
+	Autor    string `json:"autor"`
+	Data     string `json:"data"`
+	Conteudo string `json:"conteudo"`
+}
+
+type Questionario struct {
+	Id                  string `json:"id"`
+	Titulo              string `json:"titulo"`
+	Inicio              string `json:"inicio"`
+	Fim                 string `json:"fim"`
+	Tentativas          string `json:"tentativas"`
+	Situacao            string `json:"situacao"`
+	PodeResponder       bool   `json:"podeResponder"`
+	ResultadoDisponivel bool   `json:"resultadoDisponivel"`
+	acoes               []acaoLinha
+}
+
+type AlternativaQuestao struct {
+	Valor string `json:"valor"`
+	Texto string `json:"texto"`
+}
+
+type QuestaoQuestionario struct {
+	Id           string               `json:"id"`
+	Enunciado    string               `json:"enunciado"`
+	Tipo         string               `json:"tipo"`
+	Alternativas []AlternativaQuestao `json:"alternativas"`
+}
+
+type ResultadoQuestao struct {
+	Enunciado string `json:"enunciado"`
+	Resposta  string `json:"resposta"`
+	Correcao  string `json:"correcao"`
+}
+
+type ResultadoQuestionario struct {
+	Nota     string             `json:"nota"`
+	Feedback string             `json:"feedback"`
+	Questoes []ResultadoQuestao `json:"questoes"`
+}
+
+type Enquete struct {
+	Id               string `json:"id"`
+	Pergunta         string `json:"pergunta"`
+	Inicio           string `json:"inicio"`
+	Fim              string `json:"fim"`
+	Aberta           bool   `json:"aberta"`
+	ResultadoVisivel bool   `json:"resultadoVisivel"`
+	acoes            []acaoLinha
+}
+
+type OpcaoEnquete struct {
+	Valor      string `json:"valor"`
+	Texto      string `json:"texto"`
+	Votos      int    `json:"votos"`
+	Percentual string `json:"percentual"`
+}
+
+type DetalheEnquete struct {
+	Enquete Enquete        `json:"enquete"`
+	Opcoes  []OpcaoEnquete `json:"opcoes"`
+}
+
+type MensagemCaixaPostal struct {
+	Id        string `json:"id"`
+	Remetente string `json:"remetente"`
+	Assunto   string `json:"assunto"`
+	Data      string `json:"data"`
+	Lida      bool   `json:"lida"`
+	onclick   string
+	checkbox  string
+}
+
+type MensagemCaixaPostalDetalhada struct {
+	Id        string `json:"id"`
+	Remetente string `json:"remetente"`
+	Assunto   string `json:"assunto"`
+	Data      string `json:"data"`
+	Conteudo  string `json:"conteudo"`
+}
+
+type EmprestimoBiblioteca struct {
+	Id             string `json:"id"`
+	Titulo         string `json:"titulo"`
+	Autor          string `json:"autor"`
+	CodigoBarras   string `json:"codigoBarras"`
+	Biblioteca     string `json:"biblioteca"`
+	DataEmprestimo string `json:"dataEmprestimo"`
+	PrazoDevolucao string `json:"prazoDevolucao"`
+	Renovacoes     int    `json:"renovacoes"`
+	Multa          string `json:"multa"`
+	Atrasado       bool   `json:"atrasado"`
+	Renovavel      bool   `json:"renovavel"`
+	checkbox       string
+	valorCheckbox  string
+}
+
+type ResultadoRenovacao struct {
+	Renovados []EmprestimoBiblioteca `json:"renovados"`
+	Mensagens []string               `json:"mensagens"`
+}
+
+type FiltroAcervo struct {
+	Titulo  string `form:"titulo"`
+	Autor   string `form:"autor"`
+	Assunto string `form:"assunto"`
+}
+
+type ItemAcervo struct {
+	Titulo      string `json:"titulo"`
+	Autor       string `json:"autor"`
+	Edicao      string `json:"edicao"`
+	Ano         string `json:"ano"`
+	Exemplares  int    `json:"exemplares"`
+	Disponiveis int    `json:"disponiveis"`
+}
+
+type PerfilDiscente struct {
+	Nome           string `json:"nome"`
+	Matricula      string `json:"matricula"`
+	Curso          string `json:"curso"`
+	Nivel          string `json:"nivel"`
+	Status         string `json:"status"`
+	Ingresso       string `json:"ingresso"`
+	Email          string `json:"email"`
+	Campus         string `json:"campus"`
+	FotoDisponivel bool   `json:"fotoDisponivel"`
+	fotoUrl        string
+}
+
+type AtividadeComplementar struct {
+	Descricao    string `json:"descricao"`
+	Categoria    string `json:"categoria"`
+	Periodo      string `json:"periodo"`
+	CargaHoraria int    `json:"cargaHoraria"`
+	Situacao     string `json:"situacao"`
+	Status       string `json:"status"`
+}
+
+type TotalCategoriaAtividades struct {
+	Categoria    string `json:"categoria"`
+	Aprovadas    int    `json:"aprovadas"`
+	EmAnalise    int    `json:"emAnalise"`
+	Aproveitadas int    `json:"aproveitadas"`
+	Minimo       int    `json:"minimo"`
+	Maximo       int    `json:"maximo"`
+	Restante     int    `json:"restante"`
+}
+
+type ResumoAtividadesComplementares struct {
+	Atividades       []AtividadeComplementar    `json:"atividades"`
+	Categorias       []TotalCategoriaAtividades `json:"categorias"`
+	TotalAproveitado int                        `json:"totalAproveitado"`
+	TotalExigido     int                        `json:"totalExigido"`
+	TotalPendente    int                        `json:"totalPendente"`
+}
+
+type TurmaMatricula struct {
+	Id               string   `json:"id"`
+	ComponenteCodigo string   `json:"componenteCodigo"`
+	ComponenteNome   string   `json:"componenteNome"`
+	Semestre         string   `json:"semestre,omitempty"`
+	Turma            string   `json:"turma"`
+	Docente          string   `json:"docente"`
+	Horarios         []string `json:"horarios"`
+	Local            string   `json:"local"`
+	Vagas            int      `json:"vagas"`
+	Situacao         string   `json:"situacao,omitempty"`
+	checkbox         string
+	remover          string
+}
+
+type EstadoMatricula struct {
+	Periodo      string           `json:"periodo"`
+	Aberta       bool             `json:"aberta"`
+	Selecionadas []TurmaMatricula `json:"selecionadas"`
+	Mensagens    []string         `json:"mensagens"`
+}
+
+type ComprovanteMatricula struct {
+	Periodo string           `json:"periodo"`
+	Emissao string           `json:"emissao"`
+	Turmas  []TurmaMatricula `json:"turmas"`
+}
+
+type ResultadoTurmaMatricula struct {
+	Id               string   `json:"id"`
+	ComponenteCodigo string   `json:"componenteCodigo"`
+	ComponenteNome   string   `json:"componenteNome"`
+	Turma            string   `json:"turma"`
+	Status           string   `json:"status"`
+	Mensagens        []string `json:"mensagens"`
+}
+
+type ResultadoMatricula struct {
+	DryRun     bool                      `json:"dryRun"`
+	Confirmada bool                      `json:"confirmada"`
+	Turmas     []ResultadoTurmaMatricula `json:"turmas"`
+	Mensagens  []string                  `json:"mensagens"`
+}
+
+type TurmaProcessada struct {
+	ComponenteCodigo string `json:"componenteCodigo"`
+	ComponenteNome   string `json:"componenteNome"`
+	Turma            string `json:"turma"`
+	Fase             string `json:"fase,omitempty"`
+	Situacao         string `json:"situacao"`
+	Status           string `json:"status"`
+	Motivo           string `json:"motivo,omitempty"`
+}
+
+type DiffMatricula struct {
+	Aceitas        []TurmaProcessada `json:"aceitas"`
+	Recusadas      []TurmaProcessada `json:"recusadas"`
+	Aguardando     []TurmaProcessada `json:"aguardando"`
+	SemResultado   []string          `json:"semResultado"`
+	NaoSolicitadas []TurmaProcessada `json:"naoSolicitadas"`
+}
+
+type ResultadoProcessamento struct {
+	Periodo string            `json:"periodo"`
+	Turmas  []TurmaProcessada `json:"turmas"`
+	Diff    DiffMatricula     `json:"diff"`
+}
+
+type TurmaTrancamento struct {
+	Id               string `json:"id"`
+	ComponenteCodigo string `json:"componenteCodigo"`
+	ComponenteNome   string `json:"componenteNome"`
+	Turma            string `json:"turma"`
+	Elegivel         bool   `json:"elegivel"`
+	checkbox         string
+}
+
+type SolicitacaoTrancamento struct {
+	ComponenteCodigo string `json:"componenteCodigo"`
+	ComponenteNome   string `json:"componenteNome"`
+	Turma            string `json:"turma"`
+	Data             string `json:"data"`
+	Situacao         string `json:"situacao"`
+	Motivo           string `json:"motivo,omitempty"`
+}
+
+type SituacaoTrancamento struct {
+	Prazo        string                   `json:"prazo"`
+	Turmas       []TurmaTrancamento       `json:"turmas"`
+	Solicitacoes []SolicitacaoTrancamento `json:"solicitacoes"`
+	Mensagens    []string                 `json:"mensagens"`
+}
+
+type FormularioAvaliacao struct {
+	Nome       string `json:"nome"`
+	Turma      string `json:"turma,omitempty"`
+	Docente    string `json:"docente,omitempty"`
+	Situacao   string `json:"situacao"`
+	Preenchido bool   `json:"preenchido"`
+}
+
+type StatusAvaliacaoInstitucional struct {
+	Periodo     string                `json:"periodo"`
+	Aberta      bool                  `json:"aberta"`
+	Pendente    bool                  `json:"pendente"`
+	AvisoPortal bool                  `json:"avisoPortal"`
+	Preenchidos int                   `json:"preenchidos"`
+	Total       int                   `json:"total"`
+	Formularios []FormularioAvaliacao `json:"formularios"`
+	Mensagens   []string              `json:"mensagens"`
+}
+
+type SnapshotIndices struct {
+	Data    time.Time         `json:"data"`
+	Indices IndicesAcademicos `json:"indices"`
+}
+
+type PontoIndice struct {
+	Data  time.Time `json:"data"`
+	Valor float64   `json:"valor"`
+}
+
+type DefinicaoIndice struct {
+	Sigla     string `json:"sigla"`
+	Nome      string `json:"nome"`
+	Descricao string `json:"descricao"`
+}
+
+type ResultadoHipotetico struct {
+	Turma        string   `json:"turma" binding:"required"`
+	Nota         *float64 `json:"nota" binding:"required"`
+	CargaHoraria int      `json:"cargaHoraria"`
+	Situacao     string   `json:"situacao"`
+}
+
+type BaseProjecao struct {
+	ChAprovada       int  `json:"chAprovada"`
+	ChUtilizada      int  `json:"chUtilizada"`
+	PeriodosCursados int  `json:"periodosCursados"`
+	Estimada         bool `json:"estimada"`
+}
+
+type ProjecaoIndices struct {
+	Atuais      IndicesAcademicos     `json:"atuais"`
+	Projetados  IndicesAcademicos     `json:"projetados"`
+	Base        BaseProjecao          `json:"base"`
+	Turmas      []ResultadoHipotetico `json:"turmas"`
+	Observacoes []string              `json:"observacoes"`
+}
+
+type StatusSigaa struct {
+	Status            string     `json:"status"`
+	Mensagem          string     `json:"mensagem,omitempty"`
+	Disponibilidade   float64    `json:"disponibilidade"` // Fração das requisições recentes que tiveram resposta
+	LatenciaMediaMs   int64      `json:"latenciaMediaMs"`
+	LatenciaUltimaMs  int64      `json:"latenciaUltimaMs"`
+	Amostras          int        `json:"amostras"`
+	UltimaVerificacao *time.Time `json:"ultimaVerificacao,omitempty"`
+	UltimoSucesso     *time.Time `json:"ultimoSucesso,omitempty"`
+	ManutencaoDesde   *time.Time `json:"manutencaoDesde,omitempty"`
+	// Com o circuito aberto, a API recusa chamadas ao SIGAA até ProximaTentativa
+	CircuitoAberto   bool       `json:"circuitoAberto"`
+	ProximaTentativa *time.Time `json:"proximaTentativa,omitempty"`
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const NOTICIAS_POR_PAGINA = 10
+
+func parseListaNoticias(doc *goquery.Document) []NoticiaResumo {
+	noticias := []NoticiaResumo{}
+
+	table := doc.Find("table.listing, table.listagem").First()
+	headers := tableHeaders(table)
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() < len(headers) || cells.Length() < 2 {
+			return
+		}
+
+		noticia := NoticiaResumo{
+			Titulo: cellByHeader(headers, cells, "titulo"),
+			Data:   cellByHeader(headers, cells, "data"),
+			Autor:  cellByHeader(headers, cells, "autor", "cadastrad", "docente"),
+		}
+		if noticia.Titulo == "" {
+			noticia.Titulo = strings.TrimSpace(cells.First().Text())
+		}
+
+		links := row.Find("a[onclick]")
+		link := links.FilterFunction(func(j int, l *goquery.Selection) bool {
+			return strings.Contains(normalizeText(l.AttrOr("title", l.Find("img").AttrOr("title", ""))), "visualizar")
+		}).First()
+		if link.Length() == 0 {
+			link = links.First()
+		}
+		if onclick := link.AttrOr("onclick", ""); onclick != "" {
+			noticia.formVisualizar = jsfFormId(onclick)
+			noticia.paramsVisualizar = jsfParams(onclick)
+			noticia.Id = noticia.paramsVisualizar.Get("id")
+		}
+		if noticia.Id == "" {
+			noticia.Id = fmt.Sprintf("%d", i)
+		}
+
+		noticias = append(noticias, noticia)
+	})
+
+	return noticias
+}
+
+// GetNoticias lista todas as notícias da turma e devolve só a página pedida. O SIGAA não pagina essa
+// listagem: a página inteira é sempre baixada e o recorte é feito aqui, só para aliviar o cliente.
+func GetNoticias(turma TurmaData, pagina, porPagina int, jsessionid string, viewState string) ([]NoticiaResumo, int, string, string, error) {
+	doc, newJsessionid, newViewState, err := visitarMenuTurma(turma, "Notícias", jsessionid, viewState)
+	if err != nil {
+		return nil, 0, newJsessionid, newViewState, err
+	}
+
+	noticias := parseListaNoticias(doc)
+	total := len(noticias)
+
+	inicio := min((pagina-1)*porPagina, total)
+	fim := min(inicio+porPagina, total)
+	return noticias[inicio:fim], total, newJsessionid, newViewState, nil
+}
+
+func GetNoticiaDetalhe(turma TurmaData, noticiaId string, jsessionid string, viewState string) (NoticiaDetalhada, string, string, error) {
+	var detalhe NoticiaDetalhada
+
+	docTurma, jsessionid1, err := acessarTurmaVirtual(turma, jsessionid, viewState)
+	if err != nil {
+		return detalhe, jsessionid, viewState, err
+	}
+	viewState1, err := parseViewState(docTurma, "turma_"+turma.Nome)
+	if err != nil {
+		return detalhe, jsessionid1, viewState, err
+	}
+
+	docLista, jsessionid2, viewState2, err := navegarMenuTurma(docTurma, "Notícias", jsessionid1, viewState1)
+	if err != nil {
+		return detalhe, jsessionid1, viewState, err
+	}
+
+	var resumo NoticiaResumo
+	found := false
+	for _, n := range parseListaNoticias(docLista) {
+		if n.Id == noticiaId {
+			resumo, found = n, true
+			break
+		}
+	}
+	if !found || resumo.paramsVisualizar == nil {
+		return detalhe, jsessionid2, viewState, fmt.Errorf("notícia %s não encontrada na turma %s", noticiaId, turma.Nome)
+	}
+
+	docNoticia, jsessionid3, _, err := clicarLinkJsf(docLista, resumo.formVisualizar, resumo.paramsVisualizar, jsessionid2, viewState2, URL_FREQUENCIA)
+	if err != nil {
+		return detalhe, jsessionid2, viewState, fmt.Errorf("erro ao abrir notícia %s: %w", resumo.Titulo, err)
+	}
+	detalhe = parseNoticiaDetalhe(docNoticia, resumo)
+
+	_, jsessionid4, viewState4, err := getPaginaPortal(jsessionid3)
+	if err != nil {
+		return detalhe, jsessionid3, viewState, fmt.Errorf("erro ao voltar para o portal principal: %w", err)
+	}
+	return detalhe, jsessionid4, viewState4, nil
+}
+
+// parseNoticiaDetalhe lê a tabela de visualização da notícia; o que faltar vem do resumo da listagem
+func parseNoticiaDetalhe(doc *goquery.Document, resumo NoticiaResumo) NoticiaDetalhada {
+	detalhe := NoticiaDetalhada{
+		Id:       resumo.Id,
+		Titulo:   resumo.Titulo,
+		Data:     resumo.Data,
+		Autor:    resumo.Autor,
+		Conteudo: []Paragrafo{},
+	}
+
+	doc.Find("table.visualizacao tr, table.formulario tr").Each(func(i int, row *goquery.Selection) {
+		label := normalizeText(strings.TrimSuffix(strings.TrimSpace(row.Find("th").First().Text()), ":"))
+		valor := row.Find("td").First()
+		switch {
+		case label == "":
+			return
+		case strings.Contains(label, "titulo"):
+			detalhe.Titulo = strings.TrimSpace(valor.Text())
+		case strings.Contains(label, "data"):
+			detalhe.Data = strings.Join(strings.Fields(valor.Text()), " ")
+		case strings.Contains(label, "autor"), strings.Contains(label, "cadastrad"):
+			detalhe.Autor = strings.TrimSpace(valor.Text())
+		case strings.Contains(label, "noticia"), strings.Contains(label, "conteudo"), strings.Contains(label, "descricao"):
+			detalhe.Conteudo = parseRichText(valor)
+		}
+	})
+
+	if len(detalhe.Conteudo) == 0 {
+		detalhe.Conteudo = parseRichText(doc.Find(".conteudoNoticia").First())
+	}
+
+	return detalhe
+}
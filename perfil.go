@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const ACAO_DADOS_PESSOAIS = "menu_form_menu_discente_discente_menu:A]#{ alteracaoDadosDiscente.iniciar }"
+
+// parsePerfilPortal lê o cabeçalho do portal, as mesmas linhas de chave e valor usadas por parseCH
+func parsePerfilPortal(doc *goquery.Document) PerfilDiscente {
+	perfil := PerfilDiscente{
+		Nome: strings.TrimSpace(doc.Find("p.usuario span").First().Text()),
+	}
+
+	doc.Find("#agenda-docente tr").Each(func(i int, s *goquery.Selection) {
+		tds := s.Find("td")
+		if tds.Length() != 2 {
+			return
+		}
+		valor := strings.Join(strings.Fields(tds.Eq(1).Text()), " ")
+		switch strings.TrimSuffix(normalizeText(tds.Eq(0).Text()), ":") {
+		case "matricula":
+			perfil.Matricula = valor
+		case "curso":
+			perfil.Curso = valor
+		case "nivel":
+			perfil.Nivel = valor
+		case "status":
+			perfil.Status = valor
+		case "e-mail", "email":
+			perfil.Email = valor
+		case "entrada", "ingresso":
+			perfil.Ingresso = valor
+		}
+	})
+
+	// Sem foto cadastrada, o SIGAA mostra uma imagem padrão (no_picture.png)
+	src := doc.Find("#perfil-docente .foto img, div.foto img").First().AttrOr("src", "")
+	if src != "" && !strings.Contains(src, "no_picture") {
+		perfil.fotoUrl = resolveActionUrl(URL_PORTAL_DISCENTE, src)
+		perfil.FotoDisponivel = true
+	}
+
+	// Ex: "CIÊNCIA DA COMPUTAÇÃO/DC - RECIFE - BACHARELADO - Presencial"
+	if partes := strings.Split(perfil.Curso, " - "); len(partes) > 2 {
+		perfil.Campus = strings.TrimSpace(partes[1])
+	}
+
+	return perfil
+}
+
+// parseDadosPessoais completa o perfil com a página "Meus dados pessoais", que prevalece sobre o cabeçalho
+func parseDadosPessoais(doc *goquery.Document, perfil *PerfilDiscente) {
+	doc.Find("table.formulario tr, table.visualizacao tr").Each(func(i int, row *goquery.Selection) {
+		th := row.Find("th").First()
+		if th.Length() == 0 {
+			return
+		}
+		valor := strings.TrimSpace(row.Find("td").First().Text())
+		if input := row.Find("td input[type='text']").First(); input.Length() > 0 {
+			valor = strings.TrimSpace(input.AttrOr("value", ""))
+		}
+		if valor == "" {
+			return
+		}
+		valor = strings.Join(strings.Fields(valor), " ")
+
+		switch label := strings.TrimSuffix(normalizeText(th.Text()), ":"); {
+		case label == "e-mail" || label == "email":
+			perfil.Email = valor
+		case strings.Contains(label, "campus"), strings.Contains(label, "unidade"):
+			perfil.Campus = valor
+		case label == "matricula" && perfil.Matricula == "":
+			perfil.Matricula = valor
+		}
+	})
+}
+
+func GetPerfil(jsessionid string) (PerfilDiscente, string, string, error) {
+	doc, jsessionid1, viewState1, err := getPaginaPortal(jsessionid)
+	if err != nil {
+		return PerfilDiscente{}, jsessionid1, "", err
+	}
+	perfil := parsePerfilPortal(doc)
+	if perfil.Matricula == "" {
+		return perfil, jsessionid1, viewState1, fmt.Errorf("não foi possível encontrar a matrícula no portal")
+	}
+
+	docDados, jsessionid2, _, err := navegarMenuPortal(ACAO_DADOS_PESSOAIS, jsessionid1, viewState1)
+	if err != nil {
+		// Os dados do cabeçalho já bastam; a página de dados pessoais só complementa
+		return perfil, jsessionid1, viewState1, nil
+	}
+	parseDadosPessoais(docDados, &perfil)
+
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid2, viewState1)
+	return perfil, newJsessionid, newViewState, err
+}
+
+// BaixarFotoPerfil busca a foto do cabeçalho do portal com a sessão do usuário. Quem chama deve fechar o Body.
+func BaixarFotoPerfil(jsessionid string) (*http.Response, string, error) {
+	doc, jsessionid1, _, err := getPaginaPortal(jsessionid)
+	if err != nil {
+		return nil, jsessionid1, err
+	}
+	perfil := parsePerfilPortal(doc)
+	if !perfil.FotoDisponivel {
+		return nil, jsessionid1, fmt.Errorf("%w: o discente não tem foto cadastrada", ErrRequisicaoInvalida)
+	}
+
+	resp, newJsessionid, err := doSigaaRawRequest("GET", perfil.fotoUrl, jsessionid1, URL_PORTAL_DISCENTE, nil, "")
+	if err != nil {
+		return nil, newJsessionid, fmt.Errorf("erro ao baixar foto: %w", err)
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "image/") {
+		resp.Body.Close()
+		return nil, newJsessionid, fmt.Errorf("o SIGAA não devolveu uma imagem")
+	}
+	return resp, newJsessionid, nil
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// Nota final mínima para aprovação na UFRPE, depois da prova final
+	NOTA_APROVACAO = 5.0
+	// Semanas letivas por período: uma aula semanal de 1 hora soma 15 horas no período
+	SEMANAS_PERIODO = 15
+
+	SITUACAO_APROVADO  = "aprovado"
+	SITUACAO_REPROVADO = "reprovado"
+	SITUACAO_TRANCADO  = "trancado"
+)
+
+// cargaHorariaTurma estima a carga horária do período pelos horários da turma no portal
+func cargaHorariaTurma(turma TurmaData) int {
+	aulas := map[string]bool{}
+	for _, codigo := range turma.Horarios {
+		slots, err := decodeHorario(codigo)
+		if err != nil {
+			continue
+		}
+		for _, slot := range slots {
+			aulas[slotKey(slot)] = true
+		}
+	}
+	return len(aulas) * SEMANAS_PERIODO
+}
+
+// basePorCargas estima as cargas horárias acumuladas a partir do cabeçalho do portal:
+// aprovada = total do currículo - pendências; utilizada = aprovada / IECH
+func basePorCargas(ch CargasHorarias, indices IndicesAcademicos) BaseProjecao {
+	base := BaseProjecao{Estimada: true}
+	pendente := parseInt(ch.ObrigatoriaPendente) + parseInt(ch.OptativaPendente) + parseInt(ch.ComplementarPendente)
+	if total := parseInt(ch.TotalCurriculo); total > pendente {
+		base.ChAprovada = total - pendente
+	}
+	base.ChUtilizada = base.ChAprovada
+	if indices.IECH != nil && *indices.IECH > 0 {
+		base.ChUtilizada = int(math.Round(float64(base.ChAprovada) / *indices.IECH))
+	}
+	return base
+}
+
+func arredondar(valor float64) float64 {
+	return math.Round(valor*10000) / 10000
+}
+
+func indiceArredondado(valor float64) *float64 {
+	valor = arredondar(valor)
+	return &valor
+}
+
+// produtoIndices fica nulo se algum dos fatores não estiver disponível
+func produtoIndices(fatores ...*float64) *float64 {
+	produto := 1.0
+	for _, fator := range fatores {
+		if fator == nil {
+			return nil
+		}
+		produto *= *fator
+	}
+	return indiceArredondado(produto)
+}
+
+// projetarIndices recalcula os índices somando os resultados hipotéticos às cargas acumuladas:
+//
+//	MC   = Σ(nota × CH) / Σ CH, só dos componentes aprovados
+//	IRA  = Σ(nota × CH) / Σ CH, de todos os componentes cursados com nota
+//	IECH = CH aprovada / CH utilizada (aprovações, reprovações e trancamentos)
+//	IEPL = CH aprovada / (períodos cursados × CH esperada por período)
+//	IEA  = MC × IECH × IEPL e IEAN = MCN × IECH × IEPL
+//
+// A MCN depende das notas dos demais alunos do curso e fica como está. Índices que o portal
+// não mostrou continuam nulos, assim como os produtos que dependem deles.
+func projetarIndices(atuais IndicesAcademicos, base BaseProjecao, resultados []ResultadoHipotetico) (IndicesAcademicos, []string) {
+	projetados := atuais
+	var observacoes []string
+
+	var somaAprovadas, chAprovadas, somaCursadas, chCursadas float64
+	chAprovada, chUtilizada := float64(base.ChAprovada), float64(base.ChUtilizada)
+
+	for _, resultado := range resultados {
+		ch := float64(resultado.CargaHoraria)
+		chUtilizada += ch
+		if resultado.Situacao == SITUACAO_TRANCADO {
+			continue
+		}
+		if resultado.Situacao == SITUACAO_APROVADO {
+			somaAprovadas += *resultado.Nota * ch
+			chAprovadas += ch
+			chAprovada += ch
+		}
+		somaCursadas += *resultado.Nota * ch
+		chCursadas += ch
+	}
+
+	if atuais.MC != nil {
+		if chMC := float64(base.ChAprovada) + chAprovadas; chMC > 0 {
+			projetados.MC = indiceArredondado((*atuais.MC*float64(base.ChAprovada) + somaAprovadas) / chMC)
+		}
+	} else {
+		observacoes = append(observacoes, "MC não encontrada no portal; não foi projetada.")
+	}
+	if atuais.IRA != nil {
+		if chIRA := float64(base.ChUtilizada) + chCursadas; chIRA > 0 {
+			projetados.IRA = indiceArredondado((*atuais.IRA*float64(base.ChUtilizada) + somaCursadas) / chIRA)
+		}
+	} else {
+		observacoes = append(observacoes, "IRA não encontrado no portal; não foi projetado.")
+	}
+	if chUtilizada > 0 {
+		projetados.IECH = indiceArredondado(chAprovada / chUtilizada)
+	}
+
+	if base.PeriodosCursados > 0 && atuais.IEPL != nil && *atuais.IEPL > 0 && base.ChAprovada > 0 {
+		chEsperadaPeriodo := float64(base.ChAprovada) / (*atuais.IEPL * float64(base.PeriodosCursados))
+		projetados.IEPL = indiceArredondado(chAprovada / (chEsperadaPeriodo * float64(base.PeriodosCursados+1)))
+	} else {
+		observacoes = append(observacoes, "IEPL mantido: informe periodosCursados para projetá-lo.")
+	}
+
+	projetados.IEA = produtoIndices(projetados.MC, projetados.IECH, projetados.IEPL)
+	projetados.IEAN = produtoIndices(projetados.MCN, projetados.IECH, projetados.IEPL)
+	observacoes = append(observacoes, "MCN mantida: depende das notas dos demais alunos do curso.")
+
+	return projetados, observacoes
+}
+
+// turmaDoResultado acha a turma atual pelo nome: vale o nome exato e, sem ele, só um trecho que
+// identifique uma única turma, para que "Cálculo" não caia em "Cálculo II"
+func turmaDoResultado(nome string, turmas []TurmaData) (TurmaData, bool, error) {
+	normalizado := normalizeText(nome)
+	var candidatas []TurmaData
+	for _, turma := range turmas {
+		if normalizeText(turma.Nome) == normalizado {
+			return turma, true, nil
+		}
+		if strings.Contains(normalizeText(turma.Nome), normalizado) {
+			candidatas = append(candidatas, turma)
+		}
+	}
+	if len(candidatas) > 1 {
+		nomes := make([]string, len(candidatas))
+		for i, turma := range candidatas {
+			nomes[i] = turma.Nome
+		}
+		return TurmaData{}, false, fmt.Errorf("%w: %s corresponde a mais de uma turma (%s)", ErrRequisicaoInvalida, nome, strings.Join(nomes, "; "))
+	}
+	if len(candidatas) == 1 {
+		return candidatas[0], true, nil
+	}
+	return TurmaData{}, false, nil
+}
+
+// resolverResultados completa os resultados informados com a CH das turmas atuais e, com notaPadrao,
+// acrescenta as turmas do período que não foram informadas
+func resolverResultados(resultados []ResultadoHipotetico, notaPadrao *float64, turmas []TurmaData) ([]ResultadoHipotetico, error) {
+	resolvidos := []ResultadoHipotetico{}
+	usadas := map[string]bool{}
+
+	for _, resultado := range resultados {
+		if strings.TrimSpace(resultado.Turma) == "" {
+			return nil, fmt.Errorf("%w: informe o nome de cada turma", ErrRequisicaoInvalida)
+		}
+		if resultado.Nota == nil {
+			return nil, fmt.Errorf("%w: informe a nota de %s", ErrRequisicaoInvalida, resultado.Turma)
+		}
+		if *resultado.Nota < 0 || *resultado.Nota > 10 {
+			return nil, fmt.Errorf("%w: nota de %s fora do intervalo 0 a 10", ErrRequisicaoInvalida, resultado.Turma)
+		}
+		turma, encontrada, err := turmaDoResultado(resultado.Turma, turmas)
+		if err != nil {
+			return nil, err
+		}
+		if encontrada {
+			usadas[turma.Nome] = true
+			if resultado.CargaHoraria == 0 {
+				resultado.CargaHoraria = cargaHorariaTurma(turma)
+			}
+		}
+		if resultado.CargaHoraria <= 0 {
+			return nil, fmt.Errorf("%w: informe a carga horária de %s", ErrRequisicaoInvalida, resultado.Turma)
+		}
+		resolvidos = append(resolvidos, resultado)
+	}
+
+	if notaPadrao != nil {
+		for _, turma := range turmas {
+			if usadas[turma.Nome] {
+				continue
+			}
+			ch := cargaHorariaTurma(turma)
+			if ch == 0 {
+				continue
+			}
+			nota := *notaPadrao
+			resolvidos = append(resolvidos, ResultadoHipotetico{Turma: turma.Nome, Nota: &nota, CargaHoraria: ch})
+		}
+	}
+
+	for i := range resolvidos {
+		switch normalizeText(resolvidos[i].Situacao) {
+		case "":
+			resolvidos[i].Situacao = SITUACAO_REPROVADO
+			if *resolvidos[i].Nota >= NOTA_APROVACAO {
+				resolvidos[i].Situacao = SITUACAO_APROVADO
+			}
+		case SITUACAO_APROVADO, SITUACAO_REPROVADO, SITUACAO_TRANCADO:
+			resolvidos[i].Situacao = normalizeText(resolvidos[i].Situacao)
+		default:
+			return nil, fmt.Errorf("%w: situação inválida para %s: %s", ErrRequisicaoInvalida, resolvidos[i].Turma, resolvidos[i].Situacao)
+		}
+	}
+	return resolvidos, nil
+}
+
+// ProjetarIndices lê índices, cargas horárias e turmas do portal e projeta os índices com os resultados hipotéticos.
+// As cargas acumuladas podem ser informadas em base; o que faltar é estimado pelo cabeçalho do portal.
+func ProjetarIndices(resultados []ResultadoHipotetico, notaPadrao *float64, base BaseProjecao, jsessionid string) (ProjecaoIndices, string, string, error) {
+	var projecao ProjecaoIndices
+	if notaPadrao != nil && (*notaPadrao < 0 || *notaPadrao > 10) {
+		return projecao, jsessionid, "", fmt.Errorf("%w: notaPadrao fora do intervalo 0 a 10", ErrRequisicaoInvalida)
+	}
+
+	doc, newJsessionid, viewState, err := getPaginaPortal(jsessionid)
+	if err != nil {
+		return projecao, newJsessionid, "", err
+	}
+	turmas, _, err := parseTurmas(doc)
+	if err != nil {
+		return projecao, newJsessionid, viewState, fmt.Errorf("erro ao parsear turmas: %w", err)
+	}
+	projecao.Atuais = parseIndices(doc)
+
+	estimada := basePorCargas(parseCH(doc), projecao.Atuais)
+	if base.ChAprovada == 0 {
+		base.ChAprovada, base.Estimada = estimada.ChAprovada, true
+	}
+	if base.ChUtilizada == 0 {
+		base.ChUtilizada, base.Estimada = estimada.ChUtilizada, true
+	}
+	projecao.Base = base
+
+	projecao.Turmas, err = resolverResultados(resultados, notaPadrao, turmas)
+	if err != nil {
+		return projecao, newJsessionid, viewState, err
+	}
+	if len(projecao.Turmas) == 0 {
+		return projecao, newJsessionid, viewState, fmt.Errorf("%w: informe turmas ou notaPadrao", ErrRequisicaoInvalida)
+	}
+
+	projecao.Projetados, projecao.Observacoes = projetarIndices(projecao.Atuais, base, projecao.Turmas)
+	if base.Estimada {
+		projecao.Observacoes = append(projecao.Observacoes, "Cargas horárias acumuladas estimadas pelo cabeçalho do portal; informe chAprovada e chUtilizada para maior precisão.")
+	}
+	return projecao, newJsessionid, viewState, nil
+}
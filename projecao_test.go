@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestProjetarIndices(t *testing.T) {
+	atuais := IndicesAcademicos{MC: ptr(8.0), IRA: ptr(7.0), MCN: ptr(500.0), IECH: ptr(0.8), IEPL: ptr(1.0)}
+	base := BaseProjecao{ChAprovada: 1000, ChUtilizada: 1250, PeriodosCursados: 4}
+
+	tests := []struct {
+		nome       string
+		atuais     IndicesAcademicos
+		base       BaseProjecao
+		resultados []ResultadoHipotetico
+		esperados  IndicesAcademicos
+		observacao string // trecho esperado entre as observações
+	}{
+		{
+			nome:       "aprovação",
+			atuais:     atuais,
+			base:       base,
+			resultados: []ResultadoHipotetico{{Turma: "Cálculo", Nota: ptr(10.0), CargaHoraria: 60, Situacao: SITUACAO_APROVADO}},
+			esperados:  IndicesAcademicos{MC: ptr(8.1132), IRA: ptr(7.1374), MCN: ptr(500.0), IECH: ptr(0.8092), IEPL: ptr(0.848), IEA: ptr(5.5673), IEAN: ptr(343.1008)},
+		},
+		{
+			nome:       "reprovação entra no IRA e não na MC",
+			atuais:     atuais,
+			base:       base,
+			resultados: []ResultadoHipotetico{{Turma: "Cálculo", Nota: ptr(3.0), CargaHoraria: 60, Situacao: SITUACAO_REPROVADO}},
+			esperados:  IndicesAcademicos{MC: ptr(8.0), IRA: ptr(6.8168), MCN: ptr(500.0), IECH: ptr(0.7634), IEPL: ptr(0.8), IEA: ptr(4.8858), IEAN: ptr(305.36)},
+		},
+		{
+			nome:       "trancamento só conta como carga utilizada",
+			atuais:     atuais,
+			base:       base,
+			resultados: []ResultadoHipotetico{{Turma: "Cálculo", Nota: ptr(0.0), CargaHoraria: 60, Situacao: SITUACAO_TRANCADO}},
+			esperados:  IndicesAcademicos{MC: ptr(8.0), IRA: ptr(7.0), MCN: ptr(500.0), IECH: ptr(0.7634), IEPL: ptr(0.8), IEA: ptr(4.8858), IEAN: ptr(305.36)},
+		},
+		{
+			nome:       "sem períodos cursados o IEPL é mantido",
+			atuais:     atuais,
+			base:       BaseProjecao{ChAprovada: 1000, ChUtilizada: 1250},
+			resultados: []ResultadoHipotetico{{Turma: "Cálculo", Nota: ptr(10.0), CargaHoraria: 60, Situacao: SITUACAO_APROVADO}},
+			esperados:  IndicesAcademicos{MC: ptr(8.1132), IRA: ptr(7.1374), MCN: ptr(500.0), IECH: ptr(0.8092), IEPL: ptr(1.0), IEA: ptr(6.5652), IEAN: ptr(404.6)},
+			observacao: "IEPL mantido",
+		},
+		{
+			nome:       "índice ausente no portal não é projetado",
+			atuais:     IndicesAcademicos{IRA: ptr(7.0), MCN: ptr(500.0), IEPL: ptr(1.0)},
+			base:       base,
+			resultados: []ResultadoHipotetico{{Turma: "Cálculo", Nota: ptr(10.0), CargaHoraria: 60, Situacao: SITUACAO_APROVADO}},
+			esperados:  IndicesAcademicos{IRA: ptr(7.1374), MCN: ptr(500.0), IECH: ptr(0.8092), IEPL: ptr(0.848), IEAN: ptr(343.1008)},
+			observacao: "MC não encontrada",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			projetados, observacoes := projetarIndices(tt.atuais, tt.base, tt.resultados)
+			for sigla, par := range map[string][2]*float64{
+				"MC":   {projetados.MC, tt.esperados.MC},
+				"IRA":  {projetados.IRA, tt.esperados.IRA},
+				"MCN":  {projetados.MCN, tt.esperados.MCN},
+				"IECH": {projetados.IECH, tt.esperados.IECH},
+				"IEPL": {projetados.IEPL, tt.esperados.IEPL},
+				"IEA":  {projetados.IEA, tt.esperados.IEA},
+				"IEAN": {projetados.IEAN, tt.esperados.IEAN},
+			} {
+				obtido, esperado := par[0], par[1]
+				switch {
+				case esperado == nil && obtido != nil:
+					t.Errorf("%s = %v, esperava nulo", sigla, *obtido)
+				case esperado != nil && obtido == nil:
+					t.Errorf("%s nulo, esperava %v", sigla, *esperado)
+				case esperado != nil && math.Abs(*obtido-*esperado) > 1e-9:
+					t.Errorf("%s = %v, esperava %v", sigla, *obtido, *esperado)
+				}
+			}
+			if tt.observacao != "" && !strings.Contains(strings.Join(observacoes, " "), tt.observacao) {
+				t.Errorf("observações %q sem %q", observacoes, tt.observacao)
+			}
+		})
+	}
+}
+
+func TestResolverResultados(t *testing.T) {
+	turmas := []TurmaData{
+		{Nome: "CÁLCULO NUMÉRICO", Horarios: []string{"24M12"}},
+		{Nome: "CÁLCULO NUMÉRICO II", Horarios: []string{"35M12"}},
+		{Nome: "FÍSICA I", Horarios: []string{"6T123"}},
+	}
+
+	tests := []struct {
+		nome        string
+		resultados  []ResultadoHipotetico
+		notaPadrao  *float64
+		esperados   []ResultadoHipotetico // só turma, carga horária e situação são comparadas
+		erroContido string
+	}{
+		{
+			nome:       "nome exato não casa com a turma II",
+			resultados: []ResultadoHipotetico{{Turma: "Calculo Numerico", Nota: ptr(6.0)}},
+			esperados:  []ResultadoHipotetico{{Turma: "Calculo Numerico", CargaHoraria: 60, Situacao: SITUACAO_APROVADO}},
+		},
+		{
+			nome:       "trecho que identifica uma só turma",
+			resultados: []ResultadoHipotetico{{Turma: "física", Nota: ptr(4.0)}},
+			esperados:  []ResultadoHipotetico{{Turma: "física", CargaHoraria: 45, Situacao: SITUACAO_REPROVADO}},
+		},
+		{
+			nome:        "trecho ambíguo",
+			resultados:  []ResultadoHipotetico{{Turma: "Cálculo", Nota: ptr(6.0)}},
+			erroContido: "mais de uma turma",
+		},
+		{
+			nome:        "sem nota",
+			resultados:  []ResultadoHipotetico{{Turma: "FÍSICA I"}},
+			erroContido: "informe a nota",
+		},
+		{
+			nome:        "sem nome",
+			resultados:  []ResultadoHipotetico{{Turma: " ", Nota: ptr(6.0)}},
+			erroContido: "nome de cada turma",
+		},
+		{
+			nome:        "nota fora do intervalo",
+			resultados:  []ResultadoHipotetico{{Turma: "FÍSICA I", Nota: ptr(11.0)}},
+			erroContido: "fora do intervalo",
+		},
+		{
+			nome:        "turma fora do período exige carga horária",
+			resultados:  []ResultadoHipotetico{{Turma: "Química", Nota: ptr(6.0)}},
+			erroContido: "carga horária",
+		},
+		{
+			nome:        "situação inválida",
+			resultados:  []ResultadoHipotetico{{Turma: "FÍSICA I", Nota: ptr(6.0), Situacao: "dispensado"}},
+			erroContido: "situação inválida",
+		},
+		{
+			nome:       "notaPadrao completa as turmas não informadas",
+			resultados: []ResultadoHipotetico{{Turma: "FÍSICA I", Nota: ptr(9.0), Situacao: "Trancado"}},
+			notaPadrao: ptr(7.0),
+			esperados: []ResultadoHipotetico{
+				{Turma: "FÍSICA I", CargaHoraria: 45, Situacao: SITUACAO_TRANCADO},
+				{Turma: "CÁLCULO NUMÉRICO", CargaHoraria: 60, Situacao: SITUACAO_APROVADO},
+				{Turma: "CÁLCULO NUMÉRICO II", CargaHoraria: 60, Situacao: SITUACAO_APROVADO},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			resolvidos, err := resolverResultados(tt.resultados, tt.notaPadrao, turmas)
+			if tt.erroContido != "" {
+				if !errors.Is(err, ErrRequisicaoInvalida) || !strings.Contains(err.Error(), tt.erroContido) {
+					t.Fatalf("erro = %v, esperava ErrRequisicaoInvalida com %q", err, tt.erroContido)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resolvidos) != len(tt.esperados) {
+				t.Fatalf("%d resultados, esperava %d: %+v", len(resolvidos), len(tt.esperados), resolvidos)
+			}
+			for i, esperado := range tt.esperados {
+				obtido := resolvidos[i]
+				if obtido.Turma != esperado.Turma || obtido.CargaHoraria != esperado.CargaHoraria || obtido.Situacao != esperado.Situacao {
+					t.Errorf("resultado %d = %s/%d/%s, esperava %s/%d/%s", i, obtido.Turma, obtido.CargaHoraria, obtido.Situacao,
+						esperado.Turma, esperado.CargaHoraria, esperado.Situacao)
+				}
+			}
+		})
+	}
+}
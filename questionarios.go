@@ -0,0 +1,305 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	QUESTAO_UNICA        = "unica"
+	QUESTAO_MULTIPLA     = "multipla"
+	QUESTAO_DISSERTATIVA = "dissertativa"
+)
+
+var reNota = regexp.MustCompile(`(?i)nota[^:]*:\s*([\d.,]+)`)
+
+func parseQuestionarios(doc *goquery.Document) []Questionario {
+	questionarios := []Questionario{}
+
+	table := doc.Find("table.listing, table.listagem").First()
+	headers := tableHeaders(table)
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() < len(headers) || cells.Length() < 2 {
+			return
+		}
+
+		questionario := Questionario{
+			Titulo:     cellByHeader(headers, cells, "titulo", "questionario"),
+			Tentativas: cellByHeader(headers, cells, "tentativa"),
+			Situacao:   cellByHeader(headers, cells, "situacao", "status"),
+			acoes:      acoesLinha(row),
+		}
+		if questionario.Titulo == "" {
+			questionario.Titulo = strings.TrimSpace(cells.First().Text())
+		}
+
+		datas := reDataHora.FindAllString(cellByHeader(headers, cells, "periodo", "inicio", "disponivel"), -1)
+		if fim := reDataHora.FindAllString(cellByHeader(headers, cells, "fim", "termino"), -1); len(fim) > 0 {
+			datas = append(datas, fim...)
+		}
+		if len(datas) > 0 {
+			questionario.Inicio = datas[0]
+		}
+		if len(datas) > 1 {
+			questionario.Fim = datas[len(datas)-1]
+		}
+
+		questionario.PodeResponder = acaoPorRotulo(questionario.acoes, "responder", "iniciar", "continuar") != ""
+		questionario.ResultadoDisponivel = acaoPorRotulo(questionario.acoes, "resultado", "visualizar", "respostas") != ""
+
+		questionario.Id = idAcoes(questionario.acoes)
+		if questionario.Id == "" {
+			questionario.Id = fmt.Sprintf("%d", i)
+		}
+
+		questionarios = append(questionarios, questionario)
+	})
+
+	return questionarios
+}
+
+// abrirAcaoQuestionario entra na turma, abre os questionários e clica na ação pedida do questionário.
+// A sessão devolvida continua dentro da turma virtual.
+func abrirAcaoQuestionario(turma TurmaData, questionarioId string, rotulos []string, jsessionid, viewState string) (*goquery.Document, string, string, error) {
+	docTurma, jsessionid1, err := acessarTurmaVirtual(turma, jsessionid, viewState)
+	if err != nil {
+		return nil, jsessionid, viewState, err
+	}
+	viewState1, err := parseViewState(docTurma, "turma_"+turma.Nome)
+	if err != nil {
+		return nil, jsessionid1, viewState, err
+	}
+
+	docLista, jsessionid2, viewState2, err := navegarMenuTurma(docTurma, "Questionários", jsessionid1, viewState1)
+	if err != nil {
+		return nil, jsessionid1, viewState, err
+	}
+
+	for _, questionario := range parseQuestionarios(docLista) {
+		if questionario.Id != questionarioId {
+			continue
+		}
+		onclick := acaoPorRotulo(questionario.acoes, rotulos...)
+		if onclick == "" {
+			return nil, jsessionid2, viewState2, fmt.Errorf("ação '%s' indisponível para o questionário %s", rotulos[0], questionario.Titulo)
+		}
+		docAcao, jsessionid3, viewState3, err := clicarLinkJsf(docLista, jsfFormId(onclick), jsfParams(onclick), jsessionid2, viewState2, URL_FREQUENCIA)
+		if err != nil {
+			return nil, jsessionid2, viewState2, fmt.Errorf("erro ao abrir questionário %s: %w", questionario.Titulo, err)
+		}
+		return docAcao, jsessionid3, viewState3, nil
+	}
+	return nil, jsessionid2, viewState2, fmt.Errorf("questionário %s não encontrado na turma %s", questionarioId, turma.Nome)
+}
+
+// rotuloCampo devolve o texto associado a um radio/checkbox: o label[for] ou o texto ao redor
+func rotuloCampo(form *goquery.Selection, field *goquery.Selection) string {
+	if id := field.AttrOr("id", ""); id != "" {
+		if label := form.Find("label[for='" + id + "']"); label.Length() > 0 {
+			return strings.Join(strings.Fields(label.Text()), " ")
+		}
+	}
+	return strings.Join(strings.Fields(field.Parent().Text()), " ")
+}
+
+func enunciadoQuestao(field *goquery.Selection) string {
+	container := field.Closest(".questao, .pergunta, fieldset")
+	if container.Length() == 0 {
+		container = field.Closest("table")
+	}
+	if legend := container.Find("legend").First(); legend.Length() > 0 {
+		return strings.Join(strings.Fields(legend.Text()), " ")
+	}
+	if linhas := textLines(container); len(linhas) > 0 {
+		return linhas[0]
+	}
+	return ""
+}
+
+// parseQuestoes agrupa os campos do formulário de resposta pelo name: cada name é uma questão
+func parseQuestoes(form *goquery.Selection) []QuestaoQuestionario {
+	questoes := []*QuestaoQuestionario{}
+	porId := map[string]*QuestaoQuestionario{}
+
+	form.Find("input[name], textarea[name], select[name]").Each(func(i int, field *goquery.Selection) {
+		name := field.AttrOr("name", "")
+		tipoCampo := strings.ToLower(field.AttrOr("type", "text"))
+		if goquery.NodeName(field) == "input" && slices.Contains([]string{"hidden", "submit", "button", "image", "reset", "file"}, tipoCampo) {
+			return
+		}
+
+		questao, exists := porId[name]
+		if !exists {
+			questao = &QuestaoQuestionario{
+				Id:           name,
+				Enunciado:    enunciadoQuestao(field),
+				Tipo:         QUESTAO_DISSERTATIVA,
+				Alternativas: []AlternativaQuestao{},
+			}
+			switch {
+			case goquery.NodeName(field) == "select" || tipoCampo == "radio":
+				questao.Tipo = QUESTAO_UNICA
+			case tipoCampo == "checkbox":
+				questao.Tipo = QUESTAO_MULTIPLA
+			}
+			porId[name] = questao
+			questoes = append(questoes, questao)
+		}
+
+		switch {
+		case goquery.NodeName(field) == "select":
+			field.Find("option").Each(func(j int, option *goquery.Selection) {
+				if valor := option.AttrOr("value", ""); valor != "" {
+					questao.Alternativas = append(questao.Alternativas, AlternativaQuestao{Valor: valor, Texto: strings.TrimSpace(option.Text())})
+				}
+			})
+		case tipoCampo == "radio" || tipoCampo == "checkbox":
+			questao.Alternativas = append(questao.Alternativas, AlternativaQuestao{
+				Valor: field.AttrOr("value", "on"),
+				Texto: rotuloCampo(form, field),
+			})
+		}
+	})
+
+	resultado := make([]QuestaoQuestionario, len(questoes))
+	for i, questao := range questoes {
+		resultado[i] = *questao
+	}
+	return resultado
+}
+
+func formularioQuestionario(doc *goquery.Document) *goquery.Selection {
+	return doc.Find("form").FilterFunction(func(i int, form *goquery.Selection) bool {
+		return len(parseQuestoes(form)) > 0
+	}).First()
+}
+
+func GetQuestionarios(turma TurmaData, jsessionid string, viewState string) ([]Questionario, string, string, error) {
+	doc, newJsessionid, newViewState, err := visitarMenuTurma(turma, "Questionários", jsessionid, viewState)
+	if err != nil {
+		return nil, newJsessionid, newViewState, err
+	}
+	return parseQuestionarios(doc), newJsessionid, newViewState, nil
+}
+
+func GetQuestoesQuestionario(turma TurmaData, questionarioId string, jsessionid string, viewState string) ([]QuestaoQuestionario, string, string, error) {
+	doc, jsessionid1, _, err := abrirAcaoQuestionario(turma, questionarioId, []string{"responder", "iniciar", "continuar"}, jsessionid, viewState)
+	if err != nil {
+		return nil, jsessionid1, viewState, err
+	}
+	questoes := parseQuestoes(formularioQuestionario(doc))
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid1, viewState)
+	return questoes, newJsessionid, newViewState, err
+}
+
+func GetResultadoQuestionario(turma TurmaData, questionarioId string, jsessionid string, viewState string) (ResultadoQuestionario, string, string, error) {
+	doc, jsessionid1, _, err := abrirAcaoQuestionario(turma, questionarioId, []string{"resultado", "respostas", "visualizar"}, jsessionid, viewState)
+	if err != nil {
+		return ResultadoQuestionario{}, jsessionid1, viewState, err
+	}
+	resultado := parseResultadoQuestionario(doc)
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid1, viewState)
+	return resultado, newJsessionid, newViewState, err
+}
+
+func parseResultadoQuestionario(doc *goquery.Document) ResultadoQuestionario {
+	resultado := ResultadoQuestionario{Questoes: []ResultadoQuestao{}}
+
+	if matches := reNota.FindStringSubmatch(doc.Find("#conteudo, body").First().Text()); len(matches) > 1 {
+		resultado.Nota = matches[1]
+	}
+
+	doc.Find(".questao, .pergunta").Each(func(i int, container *goquery.Selection) {
+		linhas := textLines(container)
+		if len(linhas) == 0 {
+			return
+		}
+		questao := ResultadoQuestao{Enunciado: linhas[0]}
+		for _, linha := range linhas[1:] {
+			normalizada := normalizeText(linha)
+			switch {
+			case strings.HasPrefix(normalizada, "resposta"), strings.HasPrefix(normalizada, "sua resposta"):
+				_, questao.Resposta, _ = strings.Cut(linha, ":")
+				questao.Resposta = strings.TrimSpace(questao.Resposta)
+			case strings.Contains(normalizada, "correta"), strings.Contains(normalizada, "gabarito"), strings.Contains(normalizada, "feedback"):
+				questao.Correcao = strings.TrimSpace(questao.Correcao + " " + linha)
+			}
+		}
+		resultado.Questoes = append(resultado.Questoes, questao)
+	})
+
+	doc.Find("table.visualizacao tr, table.formulario tr").Each(func(i int, row *goquery.Selection) {
+		label := normalizeText(row.Find("th").First().Text())
+		if strings.Contains(label, "feedback") || strings.Contains(label, "comentario") {
+			resultado.Feedback = strings.Join(textLines(row.Find("td").First()), "\n")
+		}
+	})
+
+	return resultado
+}
+
+// ResponderQuestionario preenche o formulário do questionário com as respostas (id da questão -> valores)
+// e confirma o envio, devolvendo a mensagem do SIGAA
+func ResponderQuestionario(turma TurmaData, questionarioId string, respostas map[string][]string, jsessionid string, viewState string) (string, string, string, error) {
+	doc, jsessionid1, viewState1, err := abrirAcaoQuestionario(turma, questionarioId, []string{"responder", "iniciar", "continuar"}, jsessionid, viewState)
+	if err != nil {
+		return "", jsessionid1, viewState, err
+	}
+
+	form := formularioQuestionario(doc)
+	if form.Length() == 0 {
+		return "", jsessionid1, viewState, fmt.Errorf("formulário do questionário não encontrado")
+	}
+
+	questoes := map[string]QuestaoQuestionario{}
+	for _, questao := range parseQuestoes(form) {
+		questoes[questao.Id] = questao
+	}
+
+	payload := formPayload(form)
+	payload.Set("javax.faces.ViewState", viewState1)
+	for id, valores := range respostas {
+		questao, exists := questoes[id]
+		if !exists {
+			return "", jsessionid1, viewState, fmt.Errorf("%w: questão %s não existe neste questionário", ErrRequisicaoInvalida, id)
+		}
+		if err := validarResposta(questao, valores); err != nil {
+			return "", jsessionid1, viewState, err
+		}
+		payload[id] = valores
+	}
+
+	for _, rotulo := range []string{"Finalizar", "Enviar", "Confirmar", "Responder"} {
+		if botao := findButtonName(form, rotulo); botao != "" {
+			payload.Set(botao, form.Find("[name='"+botao+"']").AttrOr("value", rotulo))
+			break
+		}
+	}
+
+	return submeterFormularioTurma(form, payload, jsessionid1, viewState)
+}
+
+func validarResposta(questao QuestaoQuestionario, valores []string) error {
+	if questao.Tipo == QUESTAO_DISSERTATIVA {
+		if len(valores) != 1 {
+			return fmt.Errorf("%w: a questão %s espera um único texto", ErrRequisicaoInvalida, questao.Id)
+		}
+		return nil
+	}
+	if questao.Tipo == QUESTAO_UNICA && len(valores) != 1 {
+		return fmt.Errorf("%w: a questão %s aceita apenas uma alternativa", ErrRequisicaoInvalida, questao.Id)
+	}
+	for _, valor := range valores {
+		valida := slices.ContainsFunc(questao.Alternativas, func(a AlternativaQuestao) bool { return a.Valor == valor })
+		if !valida {
+			return fmt.Errorf("%w: alternativa %s inválida para a questão %s", ErrRequisicaoInvalida, valor, questao.Id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Requisições seguidas que falharam por rede ou timeout, já depois das tentativas, e abrem o circuito;
+	// enquanto aberto, as requisições falham sem chegar ao SIGAA
+	LIMIAR_FALHAS_CIRCUITO = 5
+	// Páginas de manutenção seguidas que abrem o circuito, para um falso positivo isolado não derrubar a API
+	LIMIAR_MANUTENCAO_CIRCUITO = 2
+	PAUSA_CIRCUITO             = 30 * time.Second
+)
+
+var ErrCircuitoAberto = fmt.Errorf("%w: muitas falhas seguidas, aguardando antes de tentar de novo", ErrSigaaIndisponivel)
+
+// PoliticaRetry define quantas vezes repetir um passo e quanto esperar entre as tentativas
+type PoliticaRetry struct {
+	Tentativas   int
+	EsperaBase   time.Duration // Dobra a cada tentativa, até EsperaMaxima
+	EsperaMaxima time.Duration
+	// Retry-After acima disso não é esperado: a resposta volta como erro
+	RetryAfterMaximo time.Duration
+}
+
+var politicaSigaa = PoliticaRetry{Tentativas: 3, EsperaBase: 500 * time.Millisecond, EsperaMaxima: 4 * time.Second, RetryAfterMaximo: 10 * time.Second}
+
+// O login inteiro abre uma sessão nova, então pode ser repetido mesmo com o POST das credenciais
+var politicaLogin = PoliticaRetry{Tentativas: 3, EsperaBase: time.Second, EsperaMaxima: 5 * time.Second}
+
+// espera usa backoff exponencial com jitter: metade fixa e metade aleatória, para que clientes
+// que falharam juntos não voltem todos ao mesmo tempo
+func (p PoliticaRetry) espera(tentativa int) time.Duration {
+	espera := p.EsperaMaxima
+	if tentativa < 16 && p.EsperaBase<<tentativa < p.EsperaMaxima {
+		espera = p.EsperaBase << tentativa
+	}
+	return espera/2 + rand.N(espera/2+1)
+}
+
+// repetir executa fn até dar certo ou esgotar as tentativas. classificar diz se o erro é transitório
+// e a espera mínima pedida pelo servidor, quando houver.
+func (p PoliticaRetry) repetir(descricao string, fn func(tentativa int) error, classificar func(err error) (bool, time.Duration)) error {
+	for tentativa := 0; ; tentativa++ {
+		err := fn(tentativa)
+		if err == nil {
+			return nil
+		}
+		repetivel, minimo := classificar(err)
+		if !repetivel || tentativa+1 >= p.Tentativas {
+			return err
+		}
+
+		espera := p.espera(tentativa)
+		if minimo > espera {
+			if minimo > p.RetryAfterMaximo {
+				return err
+			}
+			espera = minimo
+		}
+		fmt.Printf("%s falhou (tentativa %d de %d), repetindo em %v: %v\n", descricao, tentativa+1, p.Tentativas, espera.Round(time.Millisecond), err)
+		time.Sleep(espera)
+	}
+}
+
+// erroTransitorio marca falhas em que vale tentar de novo
+type erroTransitorio struct {
+	err        error
+	retryAfter time.Duration
+	naoEnviada bool // A conexão nem foi aberta, então o SIGAA não recebeu a requisição
+	rede       bool // Falha de rede ou timeout, sem resposta do SIGAA: é o que conta para o circuito
+}
+
+func (e *erroTransitorio) Error() string { return e.err.Error() }
+func (e *erroTransitorio) Unwrap() error { return e.err }
+
+// requisicaoRepetivel só repete GET e HEAD; os demais métodos alteram o estado do JSF e só são
+// repetidos quando não chegaram a sair daqui e o corpo pode ser reenviado
+func requisicaoRepetivel(req *http.Request, err error) (bool, time.Duration) {
+	var transitorio *erroTransitorio
+	if !errors.As(err, &transitorio) {
+		return false, 0
+	}
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true, transitorio.retryAfter
+	}
+	return transitorio.naoEnviada && req.GetBody != nil, transitorio.retryAfter
+}
+
+// falhaDeRede diz se a tentativa falhou sem resposta do SIGAA
+func falhaDeRede(err error) bool {
+	var transitorio *erroTransitorio
+	return errors.As(err, &transitorio) && transitorio.rede
+}
+
+// parseRetryAfter aceita os dois formatos do cabeçalho: segundos ou data HTTP
+func parseRetryAfter(valor string) time.Duration {
+	valor = strings.TrimSpace(valor)
+	if valor == "" {
+		return 0
+	}
+	if segundos, err := strconv.Atoi(valor); err == nil && segundos > 0 {
+		return time.Duration(segundos) * time.Second
+	}
+	if data, err := http.ParseTime(valor); err == nil {
+		return max(time.Until(data), 0)
+	}
+	return 0
+}
+
+// enviarSigaa faz uma tentativa da requisição, passando pelo circuito e alimentando o monitor.
+// Respostas 5xx e 429 voltam como erro, com o corpo já fechado. As falhas de rede não são contadas
+// aqui, e sim uma vez por requisição, por quem repete as tentativas (ver doSigaaRawRequest).
+func enviarSigaa(req *http.Request) (*http.Response, error) {
+	if err := circuitoSigaa.permitir(); err != nil {
+		return nil, err
+	}
+
+	inicio := time.Now()
+	resp, err := clienteSigaa.Do(req)
+	if err != nil {
+		// Falhas de rede e timeouts indicam o SIGAA fora do ar, não um problema da requisição
+		err = fmt.Errorf("%w: erro ao fazer requisição para %s: %w", ErrSigaaIndisponivel, req.URL, err)
+		monitorSigaa.registrar(time.Since(inicio), err)
+		var opErr *net.OpError
+		return nil, &erroTransitorio{err: err, rede: true, naoEnviada: errors.As(err, &opErr) && opErr.Op == "dial"}
+	}
+	fmt.Printf("URL: %v -- STATUS: %v\n", resp.Request.URL, resp.StatusCode)
+
+	// O SIGAA respondeu: para o circuito, que só mede falhas de rede, isso basta
+	circuitoSigaa.sucesso()
+	switch {
+	case resp.StatusCode >= http.StatusInternalServerError:
+		defer resp.Body.Close()
+		err = fmt.Errorf("%w: status code %d para %s", ErrSigaaIndisponivel, resp.StatusCode, req.URL)
+		monitorSigaa.registrar(time.Since(inicio), err)
+		if verificarRespostaIndisponivel(resp) {
+			// Em manutenção, repetir não adianta
+			circuitoSigaa.manutencao()
+			return nil, err
+		}
+		return nil, &erroTransitorio{err: err, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	case resp.StatusCode == http.StatusTooManyRequests:
+		// O SIGAA está no ar e só pediu calma
+		resp.Body.Close()
+		monitorSigaa.registrar(time.Since(inicio), nil)
+		err = fmt.Errorf("%w: o SIGAA limitou as requisições para %s", ErrSigaaIndisponivel, req.URL)
+		return nil, &erroTransitorio{err: err, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+
+	monitorSigaa.registrar(time.Since(inicio), nil)
+	return resp, nil
+}
+
+// CircuitoSigaa é o circuit breaker do sigs.ufrpe.br: depois de LIMIAR_FALHAS_CIRCUITO falhas seguidas,
+// ou de LIMIAR_MANUTENCAO_CIRCUITO páginas de manutenção seguidas, recusa as requisições por PAUSA_CIRCUITO.
+// Passada a pausa, deixa uma única requisição de teste passar; se ela funcionar o circuito fecha, senão
+// volta a abrir.
+type CircuitoSigaa struct {
+	mu               sync.Mutex
+	falhas           int
+	sinaisManutencao int
+	abertoAte        time.Time
+	testando         bool
+}
+
+var circuitoSigaa = &CircuitoSigaa{}
+
+func (c *CircuitoSigaa) permitir() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.abertoAte.IsZero() {
+		return nil
+	}
+	if c.testando || time.Now().Before(c.abertoAte) {
+		return ErrCircuitoAberto
+	}
+	c.testando = true
+	return nil
+}
+
+func (c *CircuitoSigaa) sucesso() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.abertoAte.IsZero() {
+		fmt.Println("SIGAA respondeu, fechando o circuito.")
+	}
+	c.falhas = 0
+	c.abertoAte = time.Time{}
+	c.testando = false
+}
+
+func (c *CircuitoSigaa) falha() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.falhas++
+	if c.testando || c.falhas >= LIMIAR_FALHAS_CIRCUITO {
+		c.abrirSemLock()
+	}
+}
+
+// manutencao conta uma página de manutenção. Como a página chega com uma resposta comum, o contador
+// não é zerado por sucesso, só por paginaNormal.
+func (c *CircuitoSigaa) manutencao() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sinaisManutencao++
+	if c.sinaisManutencao >= LIMIAR_MANUTENCAO_CIRCUITO {
+		c.abrirSemLock()
+	}
+}
+
+func (c *CircuitoSigaa) paginaNormal() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sinaisManutencao = 0
+}
+
+func (c *CircuitoSigaa) abrirSemLock() {
+	c.abertoAte = time.Now().Add(PAUSA_CIRCUITO)
+	c.testando = false
+	fmt.Printf("Circuito do SIGAA aberto até %s, após %d falha(s).\n", c.abertoAte.Format(time.TimeOnly), c.falhas)
+}
+
+// Estado informa se o circuito está aberto e quando a próxima requisição de teste será permitida
+func (c *CircuitoSigaa) Estado() (bool, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.abertoAte.IsZero(), c.abertoAte
+}
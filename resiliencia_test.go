@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPoliticaRetryEspera(t *testing.T) {
+	politica := PoliticaRetry{Tentativas: 5, EsperaBase: 500 * time.Millisecond, EsperaMaxima: 4 * time.Second}
+
+	tests := []struct {
+		tentativa int
+		base      time.Duration // a espera fica entre base/2 e base, por causa do jitter
+	}{
+		{tentativa: 0, base: 500 * time.Millisecond},
+		{tentativa: 1, base: time.Second},
+		{tentativa: 2, base: 2 * time.Second},
+		{tentativa: 3, base: 4 * time.Second},
+		{tentativa: 4, base: 4 * time.Second},
+		{tentativa: 40, base: 4 * time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 100 {
+			if espera := politica.espera(tt.tentativa); espera < tt.base/2 || espera > tt.base {
+				t.Fatalf("espera(%d) = %v, esperava entre %v e %v", tt.tentativa, espera, tt.base/2, tt.base)
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		valor    string
+		minimo   time.Duration
+		esperado time.Duration
+	}{
+		{valor: "", esperado: 0},
+		{valor: "5", minimo: 5 * time.Second, esperado: 5 * time.Second},
+		{valor: " 120 ", minimo: 120 * time.Second, esperado: 120 * time.Second},
+		{valor: "0", esperado: 0},
+		{valor: "-3", esperado: 0},
+		{valor: "amanhã", esperado: 0},
+		{valor: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), esperado: 0},
+		// Datas HTTP têm precisão de segundos e o tempo corre durante o teste
+		{valor: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), minimo: 58 * time.Second, esperado: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.valor, func(t *testing.T) {
+			if espera := parseRetryAfter(tt.valor); espera < tt.minimo || espera > tt.esperado {
+				t.Errorf("parseRetryAfter(%q) = %v, esperava entre %v e %v", tt.valor, espera, tt.minimo, tt.esperado)
+			}
+		})
+	}
+}
+
+func TestCircuitoSigaa(t *testing.T) {
+	tests := []struct {
+		nome   string
+		passos []func(c *CircuitoSigaa)
+		aberto bool
+	}{
+		{
+			nome:   "falhas abaixo do limiar",
+			passos: repetirPasso((*CircuitoSigaa).falha, LIMIAR_FALHAS_CIRCUITO-1),
+		},
+		{
+			nome:   "falhas no limiar",
+			passos: repetirPasso((*CircuitoSigaa).falha, LIMIAR_FALHAS_CIRCUITO),
+			aberto: true,
+		},
+		{
+			nome: "sucesso zera as falhas",
+			passos: append(append(repetirPasso((*CircuitoSigaa).falha, LIMIAR_FALHAS_CIRCUITO-1), (*CircuitoSigaa).sucesso),
+				repetirPasso((*CircuitoSigaa).falha, LIMIAR_FALHAS_CIRCUITO-1)...),
+		},
+		{
+			nome:   "uma página de manutenção isolada",
+			passos: []func(c *CircuitoSigaa){(*CircuitoSigaa).manutencao},
+		},
+		{
+			nome:   "páginas de manutenção seguidas",
+			passos: repetirPasso((*CircuitoSigaa).manutencao, LIMIAR_MANUTENCAO_CIRCUITO),
+			aberto: true,
+		},
+		{
+			nome:   "página comum entre avisos de manutenção",
+			passos: []func(c *CircuitoSigaa){(*CircuitoSigaa).manutencao, (*CircuitoSigaa).paginaNormal, (*CircuitoSigaa).manutencao},
+		},
+		{
+			nome:   "a resposta que traz o aviso não zera os avisos",
+			passos: []func(c *CircuitoSigaa){(*CircuitoSigaa).sucesso, (*CircuitoSigaa).manutencao, (*CircuitoSigaa).sucesso, (*CircuitoSigaa).manutencao},
+			aberto: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			circuito := &CircuitoSigaa{}
+			for _, passo := range tt.passos {
+				passo(circuito)
+			}
+			if aberto, _ := circuito.Estado(); aberto != tt.aberto {
+				t.Errorf("aberto = %v, esperava %v", aberto, tt.aberto)
+			}
+			if err := circuito.permitir(); tt.aberto != errors.Is(err, ErrCircuitoAberto) {
+				t.Errorf("permitir() = %v com o circuito aberto=%v", err, tt.aberto)
+			}
+		})
+	}
+}
+
+func TestCircuitoSigaaTeste(t *testing.T) {
+	circuito := &CircuitoSigaa{}
+	for range LIMIAR_FALHAS_CIRCUITO {
+		circuito.falha()
+	}
+	// Passada a pausa, só uma requisição de teste passa
+	circuito.abertoAte = time.Now().Add(-time.Second)
+	if err := circuito.permitir(); err != nil {
+		t.Fatalf("primeira requisição depois da pausa: %v", err)
+	}
+	if err := circuito.permitir(); !errors.Is(err, ErrCircuitoAberto) {
+		t.Fatalf("segunda requisição durante o teste: %v", err)
+	}
+	// Uma falha no teste reabre o circuito sem esperar pelo limiar
+	circuito.falha()
+	if aberto, ate := circuito.Estado(); !aberto || !ate.After(time.Now()) {
+		t.Fatalf("circuito deveria ter reaberto, aberto=%v até %v", aberto, ate)
+	}
+}
+
+func repetirPasso(passo func(c *CircuitoSigaa), vezes int) []func(c *CircuitoSigaa) {
+	passos := make([]func(c *CircuitoSigaa), vezes)
+	for i := range passos {
+		passos[i] = passo
+	}
+	return passos
+}
+
+func TestFalhaDeRede(t *testing.T) {
+	tests := []struct {
+		nome     string
+		err      error
+		esperado bool
+	}{
+		{nome: "rede", err: &erroTransitorio{err: ErrSigaaIndisponivel, rede: true}, esperado: true},
+		{nome: "5xx", err: &erroTransitorio{err: ErrSigaaIndisponivel}},
+		{nome: "circuito aberto", err: ErrCircuitoAberto},
+		{nome: "sem erro"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			if falhaDeRede(tt.err) != tt.esperado {
+				t.Errorf("falhaDeRede(%v) = %v, esperava %v", tt.err, !tt.esperado, tt.esperado)
+			}
+		})
+	}
+}
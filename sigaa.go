@@ -1,28 +1,35 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/PuerkitoBio/goquery"
 	"golang.org/x/net/html"
 )
 
 const (
+	URL_SIGAA           = "https://sigs.ufrpe.br"
 	URL_VIEW_LOGIN      = "https://sigs.ufrpe.br/sigaa/verTelaLogin.do"
 	URL_PORTAL_DISCENTE = "https://sigs.ufrpe.br/sigaa/portais/discente/discente.jsf"
 	URL_FREQUENCIA      = "https://sigs.ufrpe.br/sigaa/ava/index.jsf"
 	USER_AGENT          = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36"
 )
 
-func doSigaaRequest(method, url, jsessionid, referer string, body io.Reader, contentType string) (*goquery.Document, string, error) {
-	client := &http.Client{}
-
+// doSigaaRawRequest faz a requisição e devolve a resposta crua, para downloads. Falhas transitórias
+// seguem politicaSigaa e passam pelo circuito do SIGAA (ver resiliencia.go).
+// Quem chama é responsável por fechar o Body.
+func doSigaaRawRequest(method, url, jsessionid, referer string, body io.Reader, contentType string) (*http.Response, string, error) {
 	req, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return nil, jsessionid, fmt.Errorf("erro ao criar requisição: %w", err)
@@ -39,12 +46,32 @@ func doSigaaRequest(method, url, jsessionid, referer string, body io.Reader, con
 		req.Header.Set("Cookie", jsessionid)
 	}
 
-	resp, err := client.Do(req)
+	var resp *http.Response
+	var semResposta bool
+	err = politicaSigaa.repetir(method+" "+url, func(tentativa int) error {
+		if tentativa > 0 && req.GetBody != nil {
+			corpo, err := req.GetBody()
+			if err != nil {
+				return err
+			}
+			req.Body = corpo
+		}
+		var err error
+		resp, err = enviarSigaa(req)
+		if !errors.Is(err, ErrCircuitoAberto) {
+			semResposta = falhaDeRede(err)
+		}
+		return err
+	}, func(err error) (bool, time.Duration) {
+		return requisicaoRepetivel(req, err)
+	})
 	if err != nil {
-		return nil, jsessionid, fmt.Errorf("erro ao fazer requisição para %s: %w", url, err)
+		// Uma falha por requisição, já esgotadas as tentativas
+		if semResposta {
+			circuitoSigaa.falha()
+		}
+		return nil, jsessionid, err
 	}
-	fmt.Printf("URL: %v -- STATUS: %v\n", resp.Request.URL, resp.StatusCode)
-	defer resp.Body.Close()
 
 	newJsessionid := jsessionid
 	cookieHeader := resp.Header.Get("Set-Cookie")
@@ -56,25 +83,385 @@ func doSigaaRequest(method, url, jsessionid, referer string, body io.Reader, con
 	}
 
 	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
 		return nil, newJsessionid, fmt.Errorf("status code inesperado %d para %s", resp.StatusCode, url)
 	}
 
+	return resp, newJsessionid, nil
+}
+
+// doSigaaRequest busca a página e trata as páginas intermediárias conhecidas (ver intersticiais.go).
+// Só um GET passa por elas sozinho: na resposta de um POST, a página intermediária tomou o lugar do
+// resultado que quem chamou esperava, então volta como *AcaoNecessariaError.
+func doSigaaRequest(method, url, jsessionid, referer string, body io.Reader, contentType string) (*goquery.Document, string, error) {
+	doc, newJsessionid, err := requisitarPagina(method, url, jsessionid, referer, body, contentType)
+	if err != nil {
+		return nil, newJsessionid, err
+	}
+	doc, newJsessionid, _, err = tratarIntersticiais(doc, newJsessionid, method == http.MethodGet)
+	return doc, newJsessionid, err
+}
+
+// requisitarPagina faz a requisição e parseia o HTML, sem tratar páginas intermediárias.
+// doc.Url aponta para a URL final, depois dos redirecionamentos.
+func requisitarPagina(method, url, jsessionid, referer string, body io.Reader, contentType string) (*goquery.Document, string, error) {
+	resp, newJsessionid, err := doSigaaRawRequest(method, url, jsessionid, referer, body, contentType)
+	if err != nil {
+		return nil, newJsessionid, err
+	}
+	defer resp.Body.Close()
+
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
 		return nil, newJsessionid, fmt.Errorf("erro ao parsear HTML de %s: %w", url, err)
 	}
+	doc.Url = resp.Request.URL
+
+	if mensagem, ok := paginaManutencao(doc); ok {
+		monitorSigaa.registrarManutencao(mensagem)
+		circuitoSigaa.manutencao()
+		return nil, newJsessionid, fmt.Errorf("%w: %s", ErrSigaaIndisponivel, mensagem)
+	}
+	monitorSigaa.encerrarManutencao()
+	circuitoSigaa.paginaNormal()
 
 	html, _ := doc.Html()
 	if strings.Contains(html, "rio e/ou senha inv") {
 		return nil, newJsessionid, ErrInvalidCredentials
 	}
 	if strings.Contains(html, "foi expirada") {
-		return nil, newJsessionid, fmt.Errorf("sessão inválida ou expirada ao acessar %s", url)
+		return nil, newJsessionid, fmt.Errorf("%w ao acessar %s", ErrSessaoExpirada, url)
 	}
 
 	return doc, newJsessionid, nil
 }
 
+// postSigaaForm submete um formulário urlencoded e devolve a página resultante
+func postSigaaForm(actionUrl, jsessionid, referer string, payload url.Values) (*goquery.Document, string, error) {
+	return doSigaaRequest(
+		"POST",
+		actionUrl,
+		jsessionid,
+		referer,
+		strings.NewReader(payload.Encode()),
+		"application/x-www-form-urlencoded",
+	)
+}
+
+var reJsessionidUrl = regexp.MustCompile(`;jsessionid=[^?]+`)
+
+// resolveActionUrl monta a URL absoluta, sem o ;jsessionid, da action de um formulário (ou de um link)
+// a partir da página em que ele aparece. Como no navegador, a action vazia aponta para a própria página
+// e URLs com esquema (http, mailto) ficam como estão.
+func resolveActionUrl(paginaUrl, action string) string {
+	action = strings.TrimSpace(action)
+	base, err := url.Parse(paginaUrl)
+	if err != nil || base.Host == "" {
+		base, _ = url.Parse(URL_SIGAA + "/")
+	}
+	ref, err := url.Parse(action)
+	if err != nil {
+		return reJsessionidUrl.ReplaceAllString(action, "")
+	}
+	return reJsessionidUrl.ReplaceAllString(base.ResolveReference(ref).String(), "")
+}
+
+// formPayload reproduz os campos que o navegador enviaria ao submeter o formulário sem alterações.
+// Botões ficam de fora: quem chama decide qual deles foi "clicado".
+func formPayload(form *goquery.Selection) url.Values {
+	payload := url.Values{}
+	form.Find("input[name]").Each(func(i int, input *goquery.Selection) {
+		name := input.AttrOr("name", "")
+		switch strings.ToLower(input.AttrOr("type", "text")) {
+		case "submit", "button", "image", "reset", "file":
+			return
+		case "checkbox", "radio":
+			if _, checked := input.Attr("checked"); checked {
+				payload.Add(name, input.AttrOr("value", "on"))
+			}
+		default:
+			payload.Add(name, input.AttrOr("value", ""))
+		}
+	})
+	form.Find("select[name]").Each(func(i int, sel *goquery.Selection) {
+		option := sel.Find("option[selected]").First()
+		if option.Length() == 0 {
+			option = sel.Find("option").First()
+		}
+		if option.Length() > 0 {
+			payload.Set(sel.AttrOr("name", ""), option.AttrOr("value", strings.TrimSpace(option.Text())))
+		}
+	})
+	form.Find("textarea[name]").Each(func(i int, textarea *goquery.Selection) {
+		payload.Set(textarea.AttrOr("name", ""), textarea.Text())
+	})
+	return payload
+}
+
+// normalizeText deixa o texto em minúsculas, sem acentos e com espaços simples, para comparações
+func normalizeText(s string) string {
+	replacer := strings.NewReplacer(
+		"á", "a", "à", "a", "â", "a", "ã", "a",
+		"é", "e", "ê", "e",
+		"í", "i",
+		"ó", "o", "ô", "o", "õ", "o",
+		"ú", "u", "ü", "u",
+		"ç", "c",
+	)
+	return replacer.Replace(strings.Join(strings.Fields(strings.ToLower(s)), " "))
+}
+
+// findFieldName devolve o name do campo preenchível cujo id casa com a primeira palavra-chave possível,
+// respeitando a ordem das palavras-chave. Checkboxes, radios, hidden e botões ficam de fora.
+func findFieldName(form *goquery.Selection, keywords ...string) string {
+	return findFieldNameIn(form, "input[name]:not([type='checkbox'], [type='radio'], [type='hidden'], [type='submit'], [type='button'], [type='image']), select[name], textarea[name]", keywords...)
+}
+
+// findTextFieldName é como findFieldName, mas só considera campos de texto livre
+func findTextFieldName(form *goquery.Selection, keywords ...string) string {
+	return findFieldNameIn(form, "input[name]:not([type]), input[name][type='text'], textarea[name]", keywords...)
+}
+
+func findFieldNameIn(form *goquery.Selection, seletor string, keywords ...string) string {
+	fields := form.Find(seletor)
+	for _, keyword := range keywords {
+		var name string
+		fields.EachWithBreak(func(i int, field *goquery.Selection) bool {
+			if contemPalavraChave(field.AttrOr("id", field.AttrOr("name", "")), keyword) {
+				name = field.AttrOr("name", "")
+				return false
+			}
+			return true
+		})
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+var reCamelCase = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// contemPalavraChave compara pelo texto normalizado. Palavras-chave curtas ("de", "ch", "ano") precisam
+// casar com uma palavra inteira, senão "de" casaria com "descricao" e "ano" com "plano".
+func contemPalavraChave(texto, keyword string) bool {
+	keyword = normalizeText(keyword)
+	if len(keyword) > 3 {
+		return strings.Contains(normalizeText(texto), keyword)
+	}
+	palavras := strings.FieldsFunc(normalizeText(reCamelCase.ReplaceAllString(texto, "$1 $2")), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return slices.Contains(palavras, keyword)
+}
+
+// findButtonName devolve o name do botão de submit cujo texto contém o rótulo informado
+func findButtonName(form *goquery.Selection, label string) string {
+	var name string
+	form.Find("input[type='submit'], input[type='button'], button").EachWithBreak(func(i int, button *goquery.Selection) bool {
+		text := button.AttrOr("value", button.Text())
+		if strings.Contains(normalizeText(text), normalizeText(label)) {
+			name = button.AttrOr("name", "")
+			return false
+		}
+		return true
+	})
+	return name
+}
+
+// selectOptionValue escolhe a opção de um select pelo value exato ou, na falta dele, pelo texto
+func selectOptionValue(sel *goquery.Selection, query string) (string, bool) {
+	var value string
+	found := false
+	sel.Find("option").EachWithBreak(func(i int, option *goquery.Selection) bool {
+		if option.AttrOr("value", "") == query {
+			value, found = query, true
+			return false
+		}
+		return true
+	})
+	if found {
+		return value, true
+	}
+	normalizedQuery := normalizeText(query)
+	sel.Find("option").EachWithBreak(func(i int, option *goquery.Selection) bool {
+		if strings.Contains(normalizeText(option.Text()), normalizedQuery) {
+			value, found = option.AttrOr("value", ""), true
+			return false
+		}
+		return true
+	})
+	return value, found
+}
+
+var reNumero = regexp.MustCompile(`\d+`)
+
+// parseInt extrai o primeiro número inteiro do texto, ou 0 se não houver
+func parseInt(s string) int {
+	n, _ := strconv.Atoi(reNumero.FindString(s))
+	return n
+}
+
+var reDecimal = regexp.MustCompile(`-?\d+(?:[.,]\d+)?`)
+
+// parseDecimal lê o primeiro número decimal do texto (com vírgula ou ponto); ok é falso se não houver
+func parseDecimal(s string) (float64, bool) {
+	n, err := strconv.ParseFloat(strings.Replace(reDecimal.FindString(s), ",", ".", 1), 64)
+	return n, err == nil
+}
+
+// parseIndice devolve nil quando o portal não mostra o valor, para não confundi-lo com zero
+func parseIndice(s string) *float64 {
+	if n, ok := parseDecimal(s); ok {
+		return &n
+	}
+	return nil
+}
+
+// tableHeaders devolve os cabeçalhos normalizados de uma tabela de listagem do SIGAA
+func tableHeaders(table *goquery.Selection) []string {
+	headers := []string{}
+	table.Find("thead tr").Last().Find("th, td").Each(func(i int, th *goquery.Selection) {
+		headers = append(headers, normalizeText(th.Text()))
+	})
+	return headers
+}
+
+// cellByHeader devolve o texto da célula do primeiro cabeçalho que casa com as palavras-chave, em ordem
+func cellByHeader(headers []string, cells *goquery.Selection, keywords ...string) string {
+	for _, keyword := range keywords {
+		for i, header := range headers {
+			if contemPalavraChave(header, keyword) {
+				return strings.Join(strings.Fields(cells.Eq(i).Text()), " ")
+			}
+		}
+	}
+	return ""
+}
+
+var reJsfParams = regexp.MustCompile(`'([^']+)':'([^']*)'`)
+
+// jsfParams extrai os pares chave/valor que o jsfcljs de um onclick envia junto com o formulário
+func jsfParams(onclick string) url.Values {
+	params := url.Values{}
+	for _, match := range reJsfParams.FindAllStringSubmatch(onclick, -1) {
+		params.Set(match[1], match[2])
+	}
+	return params
+}
+
+var reJsfForm = regexp.MustCompile(`getElementById\('([^']+)'\)`)
+
+// jsfFormId devolve o id do formulário que o jsfcljs de um onclick submete
+func jsfFormId(onclick string) string {
+	matches := reJsfForm.FindStringSubmatch(onclick)
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1]
+}
+
+// clicarLinkJsf reproduz o clique num link jsfcljs: submete o formulário dele com os parâmetros extras
+func clicarLinkJsf(doc *goquery.Document, formId string, params url.Values, jsessionid, viewState, referer string) (*goquery.Document, string, string, error) {
+	form := doc.Find("form[id='" + formId + "']")
+	if form.Length() == 0 {
+		return nil, jsessionid, viewState, fmt.Errorf("formulário %s não encontrado", formId)
+	}
+	payload := formPayload(form)
+	for key, values := range params {
+		payload[key] = values
+	}
+	payload.Set("javax.faces.ViewState", viewState)
+
+	docDestino, newJsessionid, err := postSigaaForm(resolveActionUrl(referer, form.AttrOr("action", "")), jsessionid, referer, payload)
+	if err != nil {
+		return nil, jsessionid, viewState, err
+	}
+	newViewState, err := parseViewState(docDestino, formId)
+	if err != nil {
+		return docDestino, newJsessionid, "", err
+	}
+	return docDestino, newJsessionid, newViewState, nil
+}
+
+// clicarLinkPorTexto encontra o link jsfcljs cujo texto ou title contém o rótulo e o clica
+func clicarLinkPorTexto(doc *goquery.Document, rotulo, jsessionid, viewState, referer string) (*goquery.Document, string, string, error) {
+	var onclick string
+	doc.Find("a[onclick]").EachWithBreak(func(i int, link *goquery.Selection) bool {
+		texto := link.Text() + " " + link.AttrOr("title", "") + " " + link.Find("img").AttrOr("title", "")
+		if strings.Contains(normalizeText(texto), normalizeText(rotulo)) && strings.Contains(link.AttrOr("onclick", ""), "jsfcljs") {
+			onclick = link.AttrOr("onclick", "")
+			return false
+		}
+		return true
+	})
+	if onclick == "" {
+		return nil, jsessionid, viewState, fmt.Errorf("link '%s' não encontrado na página", rotulo)
+	}
+	return clicarLinkJsf(doc, jsfFormId(onclick), jsfParams(onclick), jsessionid, viewState, referer)
+}
+
+// clicarBotao submete, sem outras alterações, o formulário do primeiro botão cujo texto contém o rótulo
+func clicarBotao(doc *goquery.Document, rotulo, jsessionid, viewState, referer string) (*goquery.Document, string, string, error) {
+	var form *goquery.Selection
+	var botao string
+	doc.Find("form").EachWithBreak(func(i int, f *goquery.Selection) bool {
+		if name := findButtonName(f, rotulo); name != "" {
+			form, botao = f, name
+			return false
+		}
+		return true
+	})
+	if form == nil {
+		return nil, jsessionid, viewState, fmt.Errorf("botão '%s' não encontrado na página", rotulo)
+	}
+
+	payload := formPayload(form)
+	payload.Set(botao, form.Find("[name='"+botao+"']").AttrOr("value", rotulo))
+	payload.Set("javax.faces.ViewState", viewState)
+
+	docDestino, newJsessionid, err := postSigaaForm(resolveActionUrl(referer, form.AttrOr("action", "")), jsessionid, referer, payload)
+	if err != nil {
+		return nil, jsessionid, viewState, err
+	}
+	newViewState, err := parseViewState(docDestino, rotulo)
+	if err != nil {
+		return docDestino, newJsessionid, "", err
+	}
+	return docDestino, newJsessionid, newViewState, nil
+}
+
+// parseMensagensSigaa lê as caixas de mensagem que o SIGAA mostra após submeter um formulário
+func parseMensagensSigaa(doc *goquery.Document) ([]string, []string) {
+	var info, erros []string
+	doc.Find("ul.info li, ul.success li, ul.warning li").Each(func(i int, li *goquery.Selection) {
+		info = append(info, strings.Join(strings.Fields(li.Text()), " "))
+	})
+	doc.Find("ul.erros li, ul.error li, #painel-erros li").Each(func(i int, li *goquery.Selection) {
+		erros = append(erros, strings.Join(strings.Fields(li.Text()), " "))
+	})
+	return info, erros
+}
+
+var reQuebraLinha = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|tr|h[1-6])>`)
+
+// textLines devolve as linhas de texto de um elemento, respeitando <br> e fim de blocos
+func textLines(sel *goquery.Selection) []string {
+	htmlStr, _ := sel.Html()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(reQuebraLinha.ReplaceAllString(htmlStr, "\n$0")))
+	if err != nil {
+		return nil
+	}
+	var lines []string
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
 func parseViewState(doc *goquery.Document, errorContext string) (string, error) {
 	viewStateVal, exists := doc.Find("input[name='javax.faces.ViewState']").Attr("value")
 	if !exists {
@@ -83,15 +470,16 @@ func parseViewState(doc *goquery.Document, errorContext string) (string, error)
 	return viewStateVal, nil
 }
 
-func Login(username, password string) (string, error) {
+// Login abre uma sessão nova e informa se a avaliação institucional ficou pendente
+func Login(username, password string) (string, bool, error) {
 	doc, jsessionid, err := doSigaaRequest("GET", URL_VIEW_LOGIN, "", "", nil, "")
 	if err != nil {
-		return "", fmt.Errorf("erro ao carregar página de login: %w", err)
+		return "", false, fmt.Errorf("erro ao carregar página de login: %w", err)
 	}
 
 	actionUrlPath, exists := doc.Find("form[name='loginForm']").Attr("action")
 	if !exists {
-		return "", fmt.Errorf("não foi possível encontrar o formulário de login no HTML")
+		return "", false, fmt.Errorf("não foi possível encontrar o formulário de login no HTML")
 	}
 	fullActionUrl := "https://sigs.ufrpe.br" + actionUrlPath
 
@@ -108,7 +496,7 @@ func Login(username, password string) (string, error) {
 	re := regexp.MustCompile(`;jsessionid=[^?]+`)
 	cleanedActionUrl := re.ReplaceAllString(fullActionUrl, "")
 
-	doc, newJsessionid, err := doSigaaRequest(
+	docLogin, newJsessionid, err := requisitarPagina(
 		"POST",
 		cleanedActionUrl,
 		jsessionid,
@@ -117,113 +505,135 @@ func Login(username, password string) (string, error) {
 		"application/x-www-form-urlencoded",
 	)
 	if err != nil {
-		return "", fmt.Errorf("erro ao submeter login: %w", err)
+		return "", false, fmt.Errorf("erro ao submeter login: %w", err)
 	}
 
-	const selectorAviso = "input[type='submit'][value*='Continuar']"
-
-	if doc.Find(selectorAviso).Length() > 0 {
-		fmt.Println("⚠️ AvisoLogon detectado. Simulação do clique 'Continuar >>'.")
-
-		// A URL final alcançada após o POST de login será o referer
-		urlPtr := doc.Url
-		var refererAviso string
-		if urlPtr == nil {
-			// Se a URL final é nula, usamos a URL da página de login como fallback seguro.
-			// É menos preciso, mas evita o panic.
-			refererAviso = URL_VIEW_LOGIN // Use a URL de login como fallback
-		} else {
-			refererAviso = urlPtr.String() // Agora a chamada .String() é segura
-		}
-
-		// Simular o clique para prosseguir
-		_, newNewJsessionid, err := proceedFromAviso(
-			doc,
-			newJsessionid,
-			refererAviso,
-		)
-		if err != nil {
-			return "", err
-		}
-
-		// O resultado da navegação (dashboard ou falha) está em docProsseguiu
-		newJsessionid = newNewJsessionid
+	// Depois do login, as páginas intermediárias (aviso de logon e afins) são o caminho normal até o portal.
+	// A avaliação institucional é adiada com "Preencher depois", mas continua pendente para o usuário.
+	docPortal, newJsessionid, pulados, err := tratarIntersticiais(docLogin, newJsessionid, true)
+	if err != nil {
+		return "", false, fmt.Errorf("erro ao submeter login: %w", err)
 	}
-
-	return newJsessionid, nil
+	avaliacaoPendente := slices.Contains(pulados, INTERSTICIAL_AVALIACAO_INSTITUCIONAL) || avisoAvaliacaoPendente(docPortal)
+	return newJsessionid, avaliacaoPendente, nil
 }
 
-// proceedFromAviso simula o clique no botão "Continuar >>"
-func proceedFromAviso(docAviso *goquery.Document, jsessionid, refererAviso string) (*goquery.Document, string, error) {
-
-	// 1. Encontrar o formulário e a URL de action
-	// O formulário tem o ID j_id_jsp_933481798_1
-	form := docAviso.Find("form").First()
-
-	actionPath, exists := form.Attr("action")
-	if !exists {
-		return nil, jsessionid, fmt.Errorf("não foi possível encontrar a action do formulário de aviso")
+func getPaginaPortal(jsessionid string) (*goquery.Document, string, string, error) {
+	doc, newJsessionid, err := doSigaaRequest("GET", URL_PORTAL_DISCENTE, jsessionid, "", nil, "")
+	if err != nil {
+		return nil, jsessionid, "", err
+	}
+	// Sem sessão válida, o SIGAA redireciona o portal para a tela de login
+	if doc.Find("form[name='loginForm']").Length() > 0 {
+		return nil, newJsessionid, "", ErrSessaoExpirada
+	}
+	viewState, err := parseViewState(doc, "discente")
+	if err != nil {
+		return nil, newJsessionid, "", err
 	}
-	// A URL de action é relativa. Ex: /sigaa/telaAvisoLogon.jsf
-	// Assumindo o domínio: https://sigs.ufrpe.br
-	fullActionUrl := "https://sigs.ufrpe.br" + actionPath
+	return doc, newJsessionid, viewState, nil
+}
 
-	// 2. Extrair o nome dinâmico do botão "Continuar >>"
-	botaoContinuar := form.Find("input[type='submit'][value*='Continuar']").First()
+// voltarAoPortal recarrega o portal do discente para devolver um viewState utilizável pelas outras rotas
+func voltarAoPortal(jsessionid, viewState string) (string, string, error) {
+	_, newJsessionid, newViewState, err := getPaginaPortal(jsessionid)
+	if err != nil {
+		return jsessionid, viewState, fmt.Errorf("erro ao voltar para o portal principal: %w", err)
+	}
+	return newJsessionid, newViewState, nil
+}
 
-	nameBotao, exists := botaoContinuar.Attr("name")
-	if !exists {
-		// Isso deve acontecer se o seletor não funcionar ou se o HTML mudar.
-		return nil, jsessionid, fmt.Errorf("erro: não foi possível encontrar o atributo 'name' do botão 'Continuar >>'")
+// voltarAoPortalAposEscrita é o voltarAoPortal de quem já teve a escrita aceita pelo SIGAA. A falha vem
+// marcada com ErrRetornoPortal, para o handler responder sucesso com aviso e o cliente não reenviar.
+func voltarAoPortalAposEscrita(jsessionid, viewState string) (string, string, error) {
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid, viewState)
+	if err != nil {
+		return newJsessionid, newViewState, fmt.Errorf("%w: %w", ErrRetornoPortal, err)
 	}
+	return newJsessionid, newViewState, nil
+}
 
-	// 3. Preparar o payload (dados a serem enviados no POST)
+// navegarMenuPortal aciona um item do menu do portal do discente pela action do jscook_action
+func navegarMenuPortal(acao, jsessionid, viewState string) (*goquery.Document, string, string, error) {
 	payload := url.Values{}
+	payload.Set("menu:form_menu_discente", "menu:form_menu_discente")
+	payload.Set("jscook_action", acao)
+	payload.Set("javax.faces.ViewState", viewState)
+
+	doc, newJsessionid, err := postSigaaForm(URL_PORTAL_DISCENTE, jsessionid, URL_PORTAL_DISCENTE, payload)
+	if err != nil {
+		return nil, jsessionid, viewState, err
+	}
+	newViewState, err := parseViewState(doc, acao)
+	if err != nil {
+		return doc, newJsessionid, viewState, err
+	}
+	return doc, newJsessionid, newViewState, nil
+}
 
-	// a) O campo do próprio formulário (necessário para submissões JSF)
-	// O formulário tem name="j_id_jsp_933481798_1" e um hidden input com o mesmo name
-	payload.Set(form.AttrOr("name", ""), form.Find("input[type='hidden'][name='"+form.AttrOr("name", "")+"']").AttrOr("value", ""))
+// O SIGAA da UFRPE mostra datas no horário de Recife, que não tem horário de verão
+var fusoSigaa = time.FixedZone("America/Recife", -3*60*60)
 
-	// b) O campo dinâmico do botão clicado
-	payload.Set(nameBotao, "Continuar >>")
+var reData = regexp.MustCompile(`(\d{2}/\d{2}/\d{4})(?:\s+(\d{2}:\d{2}))?`)
 
-	// c) O ViewState (CRUCIAL para JSF)
-	viewStateValue := docAviso.Find("input[name='javax.faces.ViewState']").AttrOr("value", "")
-	if viewStateValue == "" {
-		return nil, jsessionid, fmt.Errorf("erro: não foi possível encontrar o campo ViewState")
+// parseDataSigaa lê a primeira data (dd/mm/aaaa, com hora opcional) do texto no horário de Recife
+func parseDataSigaa(s string) (time.Time, bool) {
+	matches := reData.FindStringSubmatch(s)
+	if matches == nil {
+		return time.Time{}, false
 	}
-	payload.Set("javax.faces.ViewState", viewStateValue)
+	layout, valor := "02/01/2006", matches[1]
+	if matches[2] != "" {
+		layout, valor = "02/01/2006 15:04", matches[1]+" "+matches[2]
+	}
+	data, err := time.ParseInLocation(layout, valor, fusoSigaa)
+	return data, err == nil
+}
 
-	// 4. Limpar action URL de JSESSIONID se necessário
-	re := regexp.MustCompile(`;jsessionid=[^?]+`)
-	cleanedActionUrl := re.ReplaceAllString(fullActionUrl, "")
+// linkAbrirLinha devolve o link jsfcljs que abre o item de uma linha de listagem (normalmente o título)
+func linkAbrirLinha(row *goquery.Selection) (string, string) {
+	link := row.Find("a[onclick*='jsfcljs']").First()
+	onclick := link.AttrOr("onclick", "")
+	return onclick, strings.Join(strings.Fields(link.Text()), " ")
+}
 
-	// 5. Executar o POST para prosseguir
-	docFinal, newJsessionid, err := doSigaaRequest(
-		"POST",
-		cleanedActionUrl,
-		jsessionid,
-		refererAviso, // Referer deve ser a URL da página de aviso (telaAvisoLogon.jsf)
-		strings.NewReader(payload.Encode()),
-		"application/x-www-form-urlencoded",
-	)
-	if err != nil {
-		return nil, newJsessionid, fmt.Errorf("erro ao simular clique em Continuar: %w", err)
-	}
+// acaoLinha é um link jsfcljs de uma linha de listagem, com o rótulo (texto ou title) normalizado
+type acaoLinha struct {
+	rotulo  string
+	onclick string
+}
 
-	return docFinal, newJsessionid, nil
+// acoesLinha devolve os links jsfcljs da linha na ordem em que aparecem
+func acoesLinha(row *goquery.Selection) []acaoLinha {
+	acoes := []acaoLinha{}
+	row.Find("a[onclick*='jsfcljs']").Each(func(i int, link *goquery.Selection) {
+		rotulo := normalizeText(link.Text() + " " + link.AttrOr("title", "") + " " + link.Find("img").AttrOr("title", ""))
+		acoes = append(acoes, acaoLinha{rotulo: rotulo, onclick: link.AttrOr("onclick", "")})
+	})
+	return acoes
 }
 
-func getPaginaPortal(jsessionid string) (*goquery.Document, string, string, error) {
-	doc, newJsessionid, err := doSigaaRequest("GET", URL_PORTAL_DISCENTE, jsessionid, "", nil, "")
-	if err != nil {
-		return nil, jsessionid, "", err
+// acaoPorRotulo devolve o onclick da primeira ação, na ordem dos rótulos e depois na da página,
+// cujo rótulo contém a palavra
+func acaoPorRotulo(acoes []acaoLinha, rotulos ...string) string {
+	for _, rotulo := range rotulos {
+		for _, acao := range acoes {
+			if strings.Contains(acao.rotulo, normalizeText(rotulo)) {
+				return acao.onclick
+			}
+		}
 	}
-	viewState, err := parseViewState(doc, "discente")
-	if err != nil {
-		return nil, newJsessionid, "", err
+	return ""
+}
+
+// idAcoes devolve o parâmetro id da primeira ação da linha que o tiver
+func idAcoes(acoes []acaoLinha) string {
+	for _, acao := range acoes {
+		if id := jsfParams(acao.onclick).Get("id"); id != "" {
+			return id
+		}
 	}
-	return doc, newJsessionid, viewState, nil
+	return ""
 }
 
 func parseTurmas(doc *goquery.Document) ([]TurmaData, []Avaliacao, error) {
@@ -324,23 +734,23 @@ func parseIndices(doc *goquery.Document) IndicesAcademicos {
 			val2 := strings.TrimSpace(tds.Eq(3).Text())
 			switch key1 {
 			case "MC:":
-				indices.MC = val1
+				indices.MC = parseIndice(val1)
 			case "MCN:":
-				indices.MCN = val1
+				indices.MCN = parseIndice(val1)
 			case "IEPL:":
-				indices.IEPL = val1
+				indices.IEPL = parseIndice(val1)
 			case "IEAN:":
-				indices.IEAN = val1
+				indices.IEAN = parseIndice(val1)
 			}
 			switch key2 {
 			case "IRA:":
-				indices.IRA = val2
+				indices.IRA = parseIndice(val2)
 			case "IECH:":
-				indices.IECH = val2
+				indices.IECH = parseIndice(val2)
 			case "IEA:":
-				indices.IEA = val2
+				indices.IEA = parseIndice(val2)
 			case "IECHP:":
-				indices.IECHP = val2
+				indices.IECHP = parseIndice(val2)
 			}
 		}
 	})
@@ -391,6 +801,14 @@ func GetMainData(jsessionid string) (string, CargasHorarias, IndicesAcademicos,
 	}
 
 	indices = parseIndices(doc)
+	if matricula := parsePerfilPortal(doc).Matricula; matricula != "" {
+		// O histórico é secundário: gravá-lo não atrasa nem derruba a resposta
+		go func() {
+			if err := indicesStore.Registrar(matricula, indices); err != nil {
+				log.Printf("erro ao registrar índices: %v", err)
+			}
+		}()
+	}
 
 	ch = parseCH(doc)
 
@@ -462,25 +880,27 @@ func parseCronograma(doc *goquery.Document) ([]CronogramaItem, error) {
 	return cronograma, nil
 }
 
-func getPaginaTurma(turma TurmaData, jsessionid string, viewState string) (Noticia, []CronogramaItem, string, string, error) {
+// acessarTurmaVirtual entra na turma virtual (formAva) a partir do portal do discente
+func acessarTurmaVirtual(turma TurmaData, jsessionid string, viewState string) (*goquery.Document, string, error) {
 	payload := url.Values{}
 	payload.Set(turma.Info.FormName, turma.Info.FormName)
 	payload.Set(turma.Info.ComponentId, turma.Info.ComponentId)
 	payload.Set("javax.faces.ViewState", viewState)
 	payload.Set("frontEndIdTurma", turma.Info.FrontEndId)
 
-	doc, newJsessionid, err := doSigaaRequest(
-		"POST",
-		URL_PORTAL_DISCENTE,
-		jsessionid,
-		URL_PORTAL_DISCENTE,
-		strings.NewReader(payload.Encode()),
-		"application/x-www-form-urlencoded",
-	)
+	doc, newJsessionid, err := postSigaaForm(URL_PORTAL_DISCENTE, jsessionid, URL_PORTAL_DISCENTE, payload)
+	if err != nil {
+		return nil, jsessionid, fmt.Errorf("erro ao acessar página da turma %s: %w", turma.Nome, err)
+	}
+	return doc, newJsessionid, nil
+}
+
+func getPaginaTurma(turma TurmaData, jsessionid string, viewState string) (Noticia, []CronogramaItem, string, string, error) {
+	doc, newJsessionid, err := acessarTurmaVirtual(turma, jsessionid, viewState)
 	if err != nil {
 		var noticia Noticia
 		var cronograma []CronogramaItem
-		return noticia, cronograma, jsessionid, viewState, fmt.Errorf("erro ao acessar página da turma %s: %w", turma.Nome, err)
+		return noticia, cronograma, jsessionid, viewState, err
 	}
 
 	newViewState, err := parseViewState(doc, "turma_"+turma.Nome)
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		texto    string
+		esperado float64
+		ok       bool
+	}{
+		{texto: "7,8543", esperado: 7.8543, ok: true},
+		{texto: "7.8543", esperado: 7.8543, ok: true},
+		{texto: " 512,3 ", esperado: 512.3, ok: true},
+		{texto: "10", esperado: 10, ok: true},
+		{texto: "0,0", esperado: 0, ok: true},
+		{texto: "-1,5", esperado: -1.5, ok: true},
+		{texto: "IRA: 8,12 (2024.1)", esperado: 8.12, ok: true},
+		{texto: "", ok: false},
+		{texto: "-", ok: false},
+		{texto: "Não informado", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.texto, func(t *testing.T) {
+			valor, ok := parseDecimal(tt.texto)
+			if ok != tt.ok || valor != tt.esperado {
+				t.Errorf("parseDecimal(%q) = %v, %v; esperava %v, %v", tt.texto, valor, ok, tt.esperado, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseIndice(t *testing.T) {
+	if indice := parseIndice("-"); indice != nil {
+		t.Errorf("parseIndice(\"-\") = %v, esperava nil", *indice)
+	}
+	if indice := parseIndice("0,0000"); indice == nil || *indice != 0 {
+		t.Errorf("parseIndice(\"0,0000\") = %v, esperava 0", indice)
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	SIGAA_ONLINE       = "online"
+	SIGAA_INSTAVEL     = "instavel"
+	SIGAA_MANUTENCAO   = "manutencao"
+	SIGAA_FORA_DO_AR   = "fora_do_ar"
+	SIGAA_DESCONHECIDO = "desconhecido"
+
+	INTERVALO_VERIFICACAO_SIGAA = time.Minute
+	AMOSTRAS_STATUS_SIGAA       = 20
+
+	// Acima disso o SIGAA é considerado instável, mesmo respondendo
+	LATENCIA_INSTAVEL = 5 * time.Second
+	// Falhas seguidas, nas amostras mais recentes, para considerar o SIGAA fora do ar
+	FALHAS_FORA_DO_AR = 3
+
+	TIMEOUT_CONEXAO_SIGAA  = 10 * time.Second
+	TIMEOUT_RESPOSTA_SIGAA = 30 * time.Second
+)
+
+// Sem timeout no client inteiro, que cortaria downloads grandes: o limite vale até os cabeçalhos da resposta
+var clienteSigaa = func() *http.Client {
+	transporte := http.DefaultTransport.(*http.Transport).Clone()
+	transporte.DialContext = (&net.Dialer{Timeout: TIMEOUT_CONEXAO_SIGAA, KeepAlive: 30 * time.Second}).DialContext
+	transporte.TLSHandshakeTimeout = TIMEOUT_CONEXAO_SIGAA
+	transporte.ResponseHeaderTimeout = TIMEOUT_RESPOSTA_SIGAA
+	return &http.Client{Transport: transporte}
+}()
+
+// Textos das páginas de manutenção e indisponibilidade, já normalizados
+var marcasManutencao = []string{"em manutencao", "temporariamente indisponivel", "sistema indisponivel", "fora do ar"}
+
+// Texto, em caracteres, acima do qual o corpo de uma página não é lido como aviso de manutenção
+const TAMANHO_MAXIMO_AVISO_MANUTENCAO = 2000
+
+type amostraSigaa struct {
+	momento  time.Time
+	latencia time.Duration
+	ok       bool
+}
+
+// MonitorSigaa acompanha a disponibilidade do SIGAA a partir das requisições feitas pela API
+// e da verificação periódica em segundo plano
+type MonitorSigaa struct {
+	mu                 sync.Mutex
+	amostras           []amostraSigaa
+	ultimoErro         string
+	ultimoSucesso      time.Time
+	manutencaoDesde    time.Time
+	mensagemManutencao string
+}
+
+var monitorSigaa = &MonitorSigaa{}
+
+func (m *MonitorSigaa) registrar(latencia time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	amostra := amostraSigaa{momento: time.Now(), latencia: latencia, ok: err == nil}
+	m.amostras = append(m.amostras, amostra)
+	if len(m.amostras) > AMOSTRAS_STATUS_SIGAA {
+		m.amostras = m.amostras[len(m.amostras)-AMOSTRAS_STATUS_SIGAA:]
+	}
+	if err != nil {
+		m.ultimoErro = err.Error()
+	} else {
+		m.ultimoSucesso = amostra.momento
+	}
+}
+
+func (m *MonitorSigaa) registrarManutencao(mensagem string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.manutencaoDesde.IsZero() {
+		m.manutencaoDesde = time.Now()
+	}
+	m.mensagemManutencao = mensagem
+}
+
+// encerrarManutencao é chamado a cada página comum recebida
+func (m *MonitorSigaa) encerrarManutencao() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.manutencaoDesde = time.Time{}
+	m.mensagemManutencao = ""
+}
+
+func (m *MonitorSigaa) Status() StatusSigaa {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := StatusSigaa{Status: SIGAA_DESCONHECIDO, Amostras: len(m.amostras)}
+	if aberto, ate := circuitoSigaa.Estado(); aberto {
+		status.CircuitoAberto = true
+		status.ProximaTentativa = &ate
+	}
+	if !m.ultimoSucesso.IsZero() {
+		ultimoSucesso := m.ultimoSucesso
+		status.UltimoSucesso = &ultimoSucesso
+	}
+	if len(m.amostras) == 0 {
+		return status
+	}
+
+	ultima := m.amostras[len(m.amostras)-1]
+	status.UltimaVerificacao = &ultima.momento
+	status.LatenciaUltimaMs = ultima.latencia.Milliseconds()
+
+	var sucessos int
+	var latenciaTotal time.Duration
+	for _, amostra := range m.amostras {
+		if amostra.ok {
+			sucessos++
+			latenciaTotal += amostra.latencia
+		}
+	}
+	status.Disponibilidade = float64(sucessos) / float64(len(m.amostras))
+	if sucessos > 0 {
+		status.LatenciaMediaMs = (latenciaTotal / time.Duration(sucessos)).Milliseconds()
+	}
+
+	falhasSeguidas := 0
+	for i := len(m.amostras) - 1; i >= 0 && !m.amostras[i].ok; i-- {
+		falhasSeguidas++
+	}
+
+	switch {
+	case !m.manutencaoDesde.IsZero():
+		status.Status = SIGAA_MANUTENCAO
+		status.Mensagem = m.mensagemManutencao
+		manutencaoDesde := m.manutencaoDesde
+		status.ManutencaoDesde = &manutencaoDesde
+	case status.CircuitoAberto || falhasSeguidas >= FALHAS_FORA_DO_AR || falhasSeguidas == len(m.amostras):
+		status.Status = SIGAA_FORA_DO_AR
+		status.Mensagem = m.ultimoErro
+	case status.Disponibilidade < 0.8 || time.Duration(status.LatenciaMediaMs)*time.Millisecond > LATENCIA_INSTAVEL:
+		status.Status = SIGAA_INSTAVEL
+		if !ultima.ok {
+			status.Mensagem = m.ultimoErro
+		}
+	default:
+		status.Status = SIGAA_ONLINE
+	}
+	return status
+}
+
+// paginaManutencao reconhece o aviso de manutenção, que é uma página curta e sem formulários. Páginas
+// com formulários são do próprio sistema funcionando, ainda que citem uma manutenção em notícias ou
+// títulos; o corpo inteiro só é considerado quando a página é curta.
+func paginaManutencao(doc *goquery.Document) (string, bool) {
+	if doc.Find("form").Length() > 0 {
+		return "", false
+	}
+	var mensagem string
+	doc.Find("title, h1, h2, h3").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		texto := strings.Join(strings.Fields(s.Text()), " ")
+		if contemMarcaManutencao(texto) {
+			mensagem = texto
+			return false
+		}
+		return true
+	})
+	if texto := strings.Join(strings.Fields(doc.Find("body").Text()), " "); mensagem == "" && len([]rune(texto)) <= TAMANHO_MAXIMO_AVISO_MANUTENCAO {
+		if contemMarcaManutencao(texto) {
+			mensagem = texto
+		}
+	}
+	if len([]rune(mensagem)) > 300 {
+		mensagem = string([]rune(mensagem)[:300]) + "..."
+	}
+	return mensagem, mensagem != ""
+}
+
+func contemMarcaManutencao(texto string) bool {
+	texto = normalizeText(texto)
+	for _, marca := range marcasManutencao {
+		if strings.Contains(texto, marca) {
+			return true
+		}
+	}
+	return false
+}
+
+// verificarRespostaIndisponivel olha o corpo de uma resposta 5xx atrás do aviso de manutenção
+func verificarRespostaIndisponivel(resp *http.Response) bool {
+	doc, err := goquery.NewDocumentFromReader(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return false
+	}
+	mensagem, ok := paginaManutencao(doc)
+	if ok {
+		monitorSigaa.registrarManutencao(mensagem)
+	}
+	return ok
+}
+
+// verificarSigaa abre a tela de login, que é pública, só para alimentar o monitor
+func verificarSigaa() {
+	if _, _, err := requisitarPagina("GET", URL_VIEW_LOGIN, "", "", nil, ""); err != nil {
+		fmt.Printf("Verificação do SIGAA falhou: %v\n", err)
+	}
+}
+
+// IniciarVerificacaoSigaa mantém o status atualizado mesmo sem tráfego de usuários
+func IniciarVerificacaoSigaa(intervalo time.Duration) {
+	go func() {
+		verificarSigaa()
+		ticker := time.NewTicker(intervalo)
+		defer ticker.Stop()
+		for range ticker.C {
+			verificarSigaa()
+		}
+	}()
+}
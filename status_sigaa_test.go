@@ -0,0 +1,34 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestPaginaManutencao(t *testing.T) {
+	tests := []struct {
+		nome     string
+		html     string
+		esperado bool
+	}{
+		{nome: "aviso de manutenção", html: `<html><head><title>SIGAA em manutenção</title></head><body><p>Voltamos logo.</p></body></html>`, esperado: true},
+		{nome: "aviso só no corpo", html: `<body><p>O sistema está temporariamente indisponível.</p></body>`, esperado: true},
+		{nome: "portal com notícia sobre manutenção", html: `<body><h3>Sistema em manutenção no sábado</h3><form action="/sigaa/portais/discente/discente.jsf"></form></body>`},
+		{nome: "página longa sem formulário", html: `<body><p>` + strings.Repeat("texto ", TAMANHO_MAXIMO_AVISO_MANUTENCAO) + `fora do ar</p></body>`},
+		{nome: "página comum", html: `<body><h2>Notas</h2></body>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := paginaManutencao(doc); ok != tt.esperado {
+				t.Errorf("paginaManutencao = %v, esperava %v", ok, tt.esperado)
+			}
+		})
+	}
+}
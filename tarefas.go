@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	TAREFA_NAO_INICIADA = "nao_iniciada"
+	TAREFA_ENVIADA      = "enviada"
+	TAREFA_PENDENTE     = "pendente"
+	TAREFA_ENCERRADA    = "encerrada"
+)
+
+var reDataHora = regexp.MustCompile(`\d{2}/\d{2}/\d{4}(?:\s+\d{2}:\d{2})?`)
+
+func GetTarefas(turma TurmaData, jsessionid string, viewState string) ([]Tarefa, string, string, error) {
+	doc, newJsessionid, newViewState, err := visitarMenuTurma(turma, "Tarefas", jsessionid, viewState)
+	if err != nil {
+		return nil, newJsessionid, newViewState, err
+	}
+	return parseTarefas(doc), newJsessionid, newViewState, nil
+}
+
+// parseTarefas lê a listagem de tarefas; a descrição de cada tarefa vem numa linha própria, logo abaixo
+func parseTarefas(doc *goquery.Document) []Tarefa {
+	tarefas := []Tarefa{}
+
+	table := doc.Find("table.listing, table.listagem").First()
+	headers := tableHeaders(table)
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() == 1 || cells.Length() < len(headers) {
+			if len(tarefas) > 0 {
+				descricao := strings.Join(textLines(cells), "\n")
+				tarefas[len(tarefas)-1].Descricao = strings.TrimSpace(tarefas[len(tarefas)-1].Descricao + "\n" + descricao)
+			}
+			return
+		}
+
+		tarefa := Tarefa{
+			Titulo:   cellByHeader(headers, cells, "titulo", "tarefa"),
+			Nota:     cellByHeader(headers, cells, "nota"),
+			Feedback: cellByHeader(headers, cells, "comentario", "feedback", "correcao"),
+		}
+		if tarefa.Titulo == "" {
+			tarefa.Titulo = strings.TrimSpace(cells.First().Text())
+		}
+
+		datas := reDataHora.FindAllString(cellByHeader(headers, cells, "periodo", "entrega", "prazo"), -1)
+		if len(datas) > 0 {
+			tarefa.Inicio = datas[0]
+		}
+		if len(datas) > 1 {
+			tarefa.Fim = datas[1]
+		}
+
+		var enviada, podeEnviar bool
+		row.Find("a[onclick], img[title]").Each(func(j int, el *goquery.Selection) {
+			acao := normalizeText(el.AttrOr("title", el.Text()))
+			if acao == "" {
+				acao = normalizeText(el.Find("img").AttrOr("title", ""))
+			}
+			switch {
+			case strings.Contains(acao, "visualizar") || strings.Contains(acao, "reenviar"):
+				enviada = true
+			case strings.Contains(acao, "enviar"):
+				podeEnviar = true
+			}
+			if strings.Contains(acao, "enviar") {
+				if onclick := el.AttrOr("onclick", ""); onclick != "" {
+					tarefa.formEnvio = jsfFormId(onclick)
+					tarefa.paramsEnvio = jsfParams(onclick)
+					tarefa.Id = tarefa.paramsEnvio.Get("id")
+				}
+			}
+		})
+		situacao := cellByHeader(headers, cells, "situacao", "status")
+		tarefa.Status = statusTarefa(situacao, enviada, podeEnviar, tarefa.Inicio, tarefa.Fim, time.Now())
+
+		tarefas = append(tarefas, tarefa)
+	})
+
+	return tarefas
+}
+
+// statusTarefa traduz a situação da listagem (quando houver) ou os links e o período da tarefa num TAREFA_*
+func statusTarefa(situacao string, enviada, podeEnviar bool, inicio, fim string, agora time.Time) string {
+	situacao = normalizeText(situacao)
+	switch {
+	case strings.Contains(situacao, "nao enviad"), strings.Contains(situacao, "pendente"):
+		return TAREFA_PENDENTE
+	case strings.Contains(situacao, "enviad"), strings.Contains(situacao, "entregue"), strings.Contains(situacao, "corrigid"):
+		return TAREFA_ENVIADA
+	case strings.Contains(situacao, "nao iniciad"), strings.Contains(situacao, "aguardando"):
+		return TAREFA_NAO_INICIADA
+	case strings.Contains(situacao, "encerrad"), strings.Contains(situacao, "expirad"), strings.Contains(situacao, "fechad"):
+		return TAREFA_ENCERRADA
+	}
+
+	if enviada {
+		return TAREFA_ENVIADA
+	}
+	if podeEnviar {
+		return TAREFA_PENDENTE
+	}
+	// Sem link de envio, o período diz se a tarefa ainda não abriu ou já fechou
+	if data, ok := parseDataSigaa(inicio); ok && agora.Before(data) {
+		return TAREFA_NAO_INICIADA
+	}
+	if data, ok := parseDataSigaa(fim); ok {
+		if !strings.Contains(fim, ":") {
+			data = data.Add(24*time.Hour - time.Second)
+		}
+		if agora.After(data) {
+			return TAREFA_ENCERRADA
+		}
+		return TAREFA_PENDENTE
+	}
+	return TAREFA_ENCERRADA
+}
+
+// Limites do envio de tarefas, checados antes de tocar no SIGAA
+const MAX_ARQUIVO_TAREFA_MB = 10
+
+var extensoesTarefa = map[string]bool{
+	".pdf": true, ".doc": true, ".docx": true, ".odt": true, ".txt": true, ".rtf": true,
+	".xls": true, ".xlsx": true, ".ods": true, ".ppt": true, ".pptx": true, ".odp": true,
+	".png": true, ".jpg": true, ".jpeg": true, ".zip": true, ".rar": true, ".7z": true,
+	".c": true, ".cpp": true, ".h": true, ".java": true, ".py": true, ".ipynb": true,
+}
+
+// ValidarArquivoTarefa confere tamanho e extensão do arquivo antes do envio
+func ValidarArquivoTarefa(nomeArquivo string, tamanho int64) error {
+	if tamanho <= 0 {
+		return fmt.Errorf("arquivo vazio")
+	}
+	if tamanho > MAX_ARQUIVO_TAREFA_MB*1024*1024 {
+		return fmt.Errorf("arquivo maior que %d MB", MAX_ARQUIVO_TAREFA_MB)
+	}
+	if !extensoesTarefa[strings.ToLower(path.Ext(nomeArquivo))] {
+		return fmt.Errorf("tipo de arquivo não permitido: %s", path.Ext(nomeArquivo))
+	}
+	return nil
+}
+
+// EnviarTarefa abre a tarefa na turma virtual, preenche o formulário de envio (arquivo e comentário)
+// e devolve a mensagem de confirmação do SIGAA
+func EnviarTarefa(turma TurmaData, tarefaId, comentario, nomeArquivo string, arquivo io.Reader, jsessionid string, viewState string) (string, string, string, error) {
+	docTurma, jsessionid1, err := acessarTurmaVirtual(turma, jsessionid, viewState)
+	if err != nil {
+		return "", jsessionid, viewState, err
+	}
+	viewState1, err := parseViewState(docTurma, "turma_"+turma.Nome)
+	if err != nil {
+		return "", jsessionid1, viewState, err
+	}
+
+	docTarefas, jsessionid2, viewState2, err := navegarMenuTurma(docTurma, "Tarefas", jsessionid1, viewState1)
+	if err != nil {
+		return "", jsessionid1, viewState, err
+	}
+
+	var tarefa Tarefa
+	found := false
+	for _, t := range parseTarefas(docTarefas) {
+		if t.Id == tarefaId || (t.Id == "" && t.Titulo == tarefaId) {
+			tarefa, found = t, true
+			break
+		}
+	}
+	if !found {
+		return "", jsessionid2, viewState, fmt.Errorf("tarefa %s não encontrada na turma %s", tarefaId, turma.Nome)
+	}
+	if tarefa.paramsEnvio == nil {
+		return "", jsessionid2, viewState, fmt.Errorf("a tarefa %s não está aberta para envio", tarefa.Titulo)
+	}
+
+	docEnvio, jsessionid3, viewState3, err := clicarLinkJsf(docTarefas, tarefa.formEnvio, tarefa.paramsEnvio, jsessionid2, viewState2, URL_FREQUENCIA)
+	if err != nil {
+		return "", jsessionid2, viewState, fmt.Errorf("erro ao abrir envio da tarefa %s: %w", tarefa.Titulo, err)
+	}
+
+	form := docEnvio.Find("input[type='file']").First().Closest("form")
+	if form.Length() == 0 {
+		return "", jsessionid3, viewState, fmt.Errorf("formulário de envio da tarefa %s não encontrado", tarefa.Titulo)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for key, values := range formPayload(form) {
+		for _, value := range values {
+			writer.WriteField(key, value)
+		}
+	}
+	writer.WriteField("javax.faces.ViewState", viewState3)
+	if name := form.Find("textarea[name]").First().AttrOr("name", ""); name != "" {
+		writer.WriteField(name, comentario)
+	}
+	if botao := findButtonName(form, "Enviar"); botao != "" {
+		writer.WriteField(botao, "Enviar")
+	}
+	fileWriter, err := writer.CreateFormFile(form.Find("input[type='file']").First().AttrOr("name", ""), nomeArquivo)
+	if err != nil {
+		return "", jsessionid3, viewState, err
+	}
+	if _, err := io.Copy(fileWriter, arquivo); err != nil {
+		return "", jsessionid3, viewState, fmt.Errorf("erro ao ler arquivo enviado: %w", err)
+	}
+	writer.Close()
+
+	docResultado, jsessionid4, err := doSigaaRequest(
+		"POST",
+		resolveActionUrl(URL_FREQUENCIA, form.AttrOr("action", "")),
+		jsessionid3,
+		URL_FREQUENCIA,
+		body,
+		writer.FormDataContentType(),
+	)
+	if err != nil {
+		return "", jsessionid3, viewState, fmt.Errorf("erro ao enviar tarefa %s: %w", tarefa.Titulo, err)
+	}
+
+	info, erros := parseMensagensSigaa(docResultado)
+	if len(erros) > 0 {
+		return "", jsessionid4, viewState, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+	}
+
+	newJsessionid, newViewState, err := voltarAoPortalAposEscrita(jsessionid4, viewState)
+	return strings.Join(info, " "), newJsessionid, newViewState, err
+}
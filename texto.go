@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+var reEspacos = regexp.MustCompile(`\s+`)
+
+// Links e imagens relativos nos textos do SIGAA são resolvidos a partir da raiz do sistema
+const URL_BASE_TEXTO = URL_SIGAA + "/sigaa/"
+
+var blocosTexto = map[string]bool{
+	"p": true, "div": true, "li": true, "br": true, "tr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// parseRichText quebra o HTML em parágrafos de trechos de texto e links, preservando os endereços
+func parseRichText(sel *goquery.Selection) []Paragrafo {
+	paragrafos := []Paragrafo{}
+	atual := Paragrafo{}
+
+	fecharParagrafo := func() {
+		trechos := []TrechoTexto{}
+		for i, trecho := range atual.Trechos {
+			if trecho.Tipo == "texto" {
+				if i == 0 {
+					trecho.Texto = strings.TrimLeft(trecho.Texto, " ")
+				}
+				if i == len(atual.Trechos)-1 {
+					trecho.Texto = strings.TrimRight(trecho.Texto, " ")
+				}
+				if trecho.Texto == "" {
+					continue
+				}
+			}
+			trechos = append(trechos, trecho)
+		}
+		if len(trechos) > 0 {
+			paragrafos = append(paragrafos, Paragrafo{Trechos: trechos})
+		}
+		atual = Paragrafo{}
+	}
+	adicionarTexto := func(texto string) {
+		texto = reEspacos.ReplaceAllString(texto, " ")
+		if texto == "" || texto == " " && len(atual.Trechos) == 0 {
+			return
+		}
+		if n := len(atual.Trechos); n > 0 && atual.Trechos[n-1].Tipo == "texto" {
+			atual.Trechos[n-1].Texto = strings.ReplaceAll(atual.Trechos[n-1].Texto+texto, "  ", " ")
+			return
+		}
+		atual.Trechos = append(atual.Trechos, TrechoTexto{Tipo: "texto", Texto: texto})
+	}
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			switch {
+			case child.Type == html.TextNode:
+				adicionarTexto(child.Data)
+			case child.Type == html.ElementNode && child.Data == "a":
+				link := goquery.NewDocumentFromNode(child).Selection
+				texto := strings.Join(strings.Fields(link.Text()), " ")
+				href, ok := urlSegura(link.AttrOr("href", ""), "http", "https", "mailto")
+				if !ok {
+					adicionarTexto(texto)
+					continue
+				}
+				if texto == "" {
+					texto = href
+				}
+				atual.Trechos = append(atual.Trechos, TrechoTexto{Tipo: "link", Texto: texto, Url: href})
+			case child.Type == html.ElementNode && (child.Data == "script" || child.Data == "style"):
+				continue
+			case child.Type == html.ElementNode && blocosTexto[child.Data]:
+				fecharParagrafo()
+				walk(child)
+				fecharParagrafo()
+			default:
+				walk(child)
+			}
+		}
+	}
+	for _, node := range sel.Nodes {
+		walk(node)
+	}
+	fecharParagrafo()
+
+	return paragrafos
+}
+
+// urlSegura resolve o link e só o aceita com um dos esquemas permitidos. javascript:, data:, vbscript:
+// e afins (em qualquer caixa) são recusados, assim como âncoras internas da página.
+func urlSegura(href string, esquemas ...string) (string, bool) {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") {
+		return "", false
+	}
+	if esquema, _, ok := strings.Cut(strings.ToLower(href), ":"); ok && !strings.ContainsAny(esquema, "/?#") {
+		if !slices.Contains(esquemas, esquema) {
+			return "", false
+		}
+	}
+	resolvida := resolveActionUrl(URL_BASE_TEXTO, href)
+	u, err := url.Parse(resolvida)
+	if err != nil || !slices.Contains(esquemas, strings.ToLower(u.Scheme)) {
+		return "", false
+	}
+	return resolvida, true
+}
+
+var reLinhasVazias = regexp.MustCompile(`\n{3,}`)
+
+// O texto das mensagens é escapado para que nada nele vire marcação: "&lt;img onerror=...&gt;" no HTML
+// do SIGAA chega aqui como "<img onerror=...>" e não pode sair como HTML vivo no Markdown
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "&", `\&`, "!", `\!`, "#", `\#`, "|", `\|`, "~", `\~`,
+)
+
+// Espaços, parênteses e sinais de maior/menor fechariam o destino do link no Markdown
+var destinoMarkdownEscaper = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E")
+
+var reCrases = regexp.MustCompile("`+")
+
+// cercaCodigo devolve uma sequência de crases mais longa que qualquer uma dentro do código
+func cercaCodigo(codigo string, minimo int) string {
+	maior := 0
+	for _, crases := range reCrases.FindAllString(codigo, -1) {
+		maior = max(maior, len(crases))
+	}
+	return strings.Repeat("`", max(minimo, maior+1))
+}
+
+// htmlToMarkdown converte o HTML de mensagens em Markdown, descartando scripts, estilos e atributos.
+// Texto é escapado e só links http(s)/mailto e imagens http(s) são mantidos.
+func htmlToMarkdown(sel *goquery.Selection) string {
+	var sb strings.Builder
+	for _, node := range sel.Nodes {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			escreverMarkdown(&sb, child, "")
+		}
+	}
+	markdown := reLinhasVazias.ReplaceAllString(sb.String(), "\n\n")
+	return strings.TrimSpace(markdown)
+}
+
+func escreverMarkdown(sb *strings.Builder, node *html.Node, prefixoLista string) {
+	filhos := func(prefixo string) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			escreverMarkdown(sb, child, prefixo)
+		}
+	}
+	textoFilhos := func() string {
+		var inner strings.Builder
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			escreverMarkdown(&inner, child, prefixoLista)
+		}
+		return strings.TrimSpace(inner.String())
+	}
+
+	if node.Type == html.TextNode {
+		sb.WriteString(markdownEscaper.Replace(reEspacos.ReplaceAllString(node.Data, " ")))
+		return
+	}
+	if node.Type != html.ElementNode {
+		return
+	}
+
+	el := goquery.NewDocumentFromNode(node).Selection
+	switch node.Data {
+	case "script", "style", "iframe", "object", "embed", "form", "input", "button":
+		return
+	case "br":
+		sb.WriteString("  \n")
+	case "p", "div":
+		sb.WriteString("\n\n")
+		filhos(prefixoLista)
+		sb.WriteString("\n\n")
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		sb.WriteString("\n\n" + strings.Repeat("#", int(node.Data[1]-'0')) + " " + textoFilhos() + "\n\n")
+	case "strong", "b":
+		if texto := textoFilhos(); texto != "" {
+			sb.WriteString("**" + texto + "**")
+		}
+	case "em", "i":
+		if texto := textoFilhos(); texto != "" {
+			sb.WriteString("_" + texto + "_")
+		}
+	case "code":
+		// Dentro de código nada é interpretado; basta que a cerca não possa ser fechada pelo conteúdo
+		cerca := cercaCodigo(el.Text(), 1)
+		sb.WriteString(cerca + " " + el.Text() + " " + cerca)
+	case "pre":
+		cerca := cercaCodigo(el.Text(), 3)
+		sb.WriteString("\n\n" + cerca + "\n" + el.Text() + "\n" + cerca + "\n\n")
+	case "blockquote":
+		texto := textoFilhos()
+		sb.WriteString("\n\n> " + strings.ReplaceAll(texto, "\n", "\n> ") + "\n\n")
+	case "ul", "ol":
+		sb.WriteString("\n")
+		numero := 1
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode || child.Data != "li" {
+				continue
+			}
+			marcador := "- "
+			if node.Data == "ol" {
+				marcador = fmt.Sprintf("%d. ", numero)
+				numero++
+			}
+			var item strings.Builder
+			for grandchild := child.FirstChild; grandchild != nil; grandchild = grandchild.NextSibling {
+				escreverMarkdown(&item, grandchild, prefixoLista+"  ")
+			}
+			sb.WriteString(prefixoLista + marcador + strings.TrimSpace(item.String()) + "\n")
+		}
+		sb.WriteString("\n")
+	case "a":
+		texto := textoFilhos()
+		href, ok := urlSegura(el.AttrOr("href", ""), "http", "https", "mailto")
+		if !ok {
+			sb.WriteString(texto)
+			return
+		}
+		if texto == "" {
+			texto = markdownEscaper.Replace(href)
+		}
+		sb.WriteString("[" + texto + "](" + destinoMarkdownEscaper.Replace(href) + ")")
+	case "img":
+		if src, ok := urlSegura(el.AttrOr("src", ""), "http", "https"); ok {
+			alt := markdownEscaper.Replace(strings.Join(strings.Fields(el.AttrOr("alt", "")), " "))
+			sb.WriteString("![" + alt + "](" + destinoMarkdownEscaper.Replace(src) + ")")
+		}
+	default:
+		filhos(prefixoLista)
+	}
+}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// textoParaHtml transforma o texto enviado pelo app em parágrafos HTML, como o editor do SIGAA espera
+func textoParaHtml(texto string) string {
+	var sb strings.Builder
+	for _, paragrafo := range strings.Split(strings.ReplaceAll(texto, "\r\n", "\n"), "\n\n") {
+		paragrafo = strings.TrimSpace(paragrafo)
+		if paragrafo == "" {
+			continue
+		}
+		linhas := strings.Split(htmlEscaper.Replace(paragrafo), "\n")
+		sb.WriteString("<p>" + strings.Join(linhas, "<br />") + "</p>")
+	}
+	return sb.String()
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestHtmlToMarkdown(t *testing.T) {
+	tests := []struct {
+		nome     string
+		html     string
+		esperado string
+	}{
+		{nome: "negrito", html: `<p>Olá <b>mundo</b></p>`, esperado: "Olá **mundo**"},
+		{nome: "lista", html: `<ul><li>um</li><li>dois</li></ul>`, esperado: "- um\n- dois"},
+		{nome: "link https", html: `<a href="https://ufrpe.br/x y">site</a>`, esperado: "[site](https://ufrpe.br/x%20y)"},
+		{nome: "link relativo ao SIGAA", html: `<a href="/sigaa/verArquivo?id=1">arq</a>`, esperado: "[arq](https://sigs.ufrpe.br/sigaa/verArquivo?id=1)"},
+		{nome: "mailto", html: `<a href="mailto:a@b.com">mail</a>`, esperado: "[mail](mailto:a@b.com)"},
+		{nome: "parêntese no destino", html: `<a href="https://x.com/a)b">p</a>`, esperado: "[p](https://x.com/a%29b)"},
+		{nome: "javascript com espaços e maiúsculas", html: `<a href="  JavaScript:alert(1)">clique</a>`, esperado: "clique"},
+		{nome: "data", html: `<a href="data:text/html,oi">d</a>`, esperado: "d"},
+		{nome: "imagem javascript", html: `<img src="javascript:alert(1)" alt="x">`, esperado: ""},
+		{nome: "imagem mailto", html: `<img src="mailto:a@b.com" alt="x">`, esperado: ""},
+		{nome: "alt escapado", html: `<img src="https://x.com/a.png" alt="a]b">`, esperado: `![a\]b](https://x.com/a.png)`},
+		{
+			nome:     "markdown no texto é escapado",
+			html:     `<p>*negrito* _x_ [link](javascript:alert(1)) &lt;script&gt;</p>`,
+			esperado: `\*negrito\* \_x\_ \[link\](javascript:alert(1)) \<script\>`,
+		},
+		{nome: "crases no código", html: "<code>a `b` c</code>", esperado: "`` a `b` c ``"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if markdown := htmlToMarkdown(doc.Find("body")); markdown != tt.esperado {
+				t.Errorf("htmlToMarkdown(%s) = %q, esperava %q", tt.html, markdown, tt.esperado)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	ACAO_SOLICITAR_TRANCAMENTO = "menu_form_menu_discente_discente_menu:A]#{ trancamentoMatricula.popularSolicitacao }"
+	ACAO_ANDAMENTO_TRANCAMENTO = "menu_form_menu_discente_discente_menu:A]#{ trancamentoMatricula.exibirAndamentoTrancamento }"
+)
+
+// separarComponente divide "06232 - CÁLCULO NUMÉRICO" em código e nome
+func separarComponente(texto string) (string, string) {
+	partes := strings.SplitN(texto, " - ", 2)
+	if len(partes) < 2 {
+		return strings.TrimSpace(partes[0]), ""
+	}
+	return strings.TrimSpace(partes[0]), strings.TrimSpace(partes[1])
+}
+
+// parseTurmasTrancamento lê as turmas matriculadas da solicitação; só as elegíveis têm checkbox habilitado
+func parseTurmasTrancamento(doc *goquery.Document) []TurmaTrancamento {
+	turmas := []TurmaTrancamento{}
+
+	table := doc.Find("table.listagem, table.listing").First()
+	headers := tableHeaders(table)
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() == 1 || cells.Length() < len(headers) {
+			return
+		}
+
+		turma := TurmaTrancamento{Turma: cellByHeader(headers, cells, "turma")}
+		turma.ComponenteCodigo, turma.ComponenteNome = separarComponente(cellByHeader(headers, cells, "componente", "disciplina"))
+		if turma.ComponenteCodigo == "" {
+			return
+		}
+		if checkbox := row.Find("input[type='checkbox'], input[type='radio']").First(); checkbox.Length() > 0 {
+			_, disabled := checkbox.Attr("disabled")
+			turma.Elegivel = !disabled
+			turma.checkbox = checkbox.AttrOr("name", "")
+			turma.Id = checkbox.AttrOr("value", "")
+		}
+		if turma.Id == "" {
+			turma.Id = chaveTurma(turma.ComponenteCodigo, turma.Turma)
+		}
+		turmas = append(turmas, turma)
+	})
+
+	return turmas
+}
+
+// parsePrazoTrancamento procura a frase do prazo ("... trancamento ... até 15/05/2025") na página
+func parsePrazoTrancamento(doc *goquery.Document) string {
+	for _, linha := range textLines(doc.Find("body")) {
+		if !strings.Contains(normalizeText(linha), "prazo") {
+			continue
+		}
+		if datas := reDataHora.FindAllString(linha, -1); len(datas) > 0 {
+			return datas[len(datas)-1]
+		}
+	}
+	return ""
+}
+
+func parseSolicitacoesTrancamento(doc *goquery.Document) []SolicitacaoTrancamento {
+	solicitacoes := []SolicitacaoTrancamento{}
+
+	table := doc.Find("table.listagem, table.listing").First()
+	headers := tableHeaders(table)
+
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if cells.Length() == 1 || cells.Length() < len(headers) {
+			return
+		}
+		solicitacao := SolicitacaoTrancamento{
+			Turma:    cellByHeader(headers, cells, "turma"),
+			Data:     reDataHora.FindString(cellByHeader(headers, cells, "data", "solicitad")),
+			Situacao: cellByHeader(headers, cells, "situacao", "status"),
+			Motivo:   cellByHeader(headers, cells, "motivo", "justificativa"),
+		}
+		solicitacao.ComponenteCodigo, solicitacao.ComponenteNome = separarComponente(cellByHeader(headers, cells, "componente", "disciplina"))
+		if solicitacao.ComponenteCodigo == "" {
+			return
+		}
+		solicitacoes = append(solicitacoes, solicitacao)
+	})
+
+	return solicitacoes
+}
+
+// GetSituacaoTrancamento junta as turmas elegíveis e o prazo da tela de solicitação com o andamento
+// das solicitações já feitas. Fora do prazo, a tela de solicitação só traz a mensagem do SIGAA.
+func GetSituacaoTrancamento(jsessionid string, viewState string) (SituacaoTrancamento, string, string, error) {
+	situacao := SituacaoTrancamento{
+		Turmas:       []TurmaTrancamento{},
+		Solicitacoes: []SolicitacaoTrancamento{},
+		Mensagens:    []string{},
+	}
+
+	docSolicitacao, jsessionid1, _, err := navegarMenuPortal(ACAO_SOLICITAR_TRANCAMENTO, jsessionid, viewState)
+	if docSolicitacao == nil {
+		return situacao, jsessionid1, viewState, fmt.Errorf("erro ao acessar solicitação de trancamento: %w", err)
+	}
+	info, erros := parseMensagensSigaa(docSolicitacao)
+	situacao.Mensagens = append(append(situacao.Mensagens, erros...), info...)
+	situacao.Prazo = parsePrazoTrancamento(docSolicitacao)
+	situacao.Turmas = parseTurmasTrancamento(docSolicitacao)
+
+	jsessionid2, viewState2, err := voltarAoPortal(jsessionid1, viewState)
+	if err != nil {
+		return situacao, jsessionid2, viewState2, err
+	}
+
+	docAndamento, jsessionid3, _, err := navegarMenuPortal(ACAO_ANDAMENTO_TRANCAMENTO, jsessionid2, viewState2)
+	if docAndamento == nil {
+		return situacao, jsessionid3, viewState2, fmt.Errorf("erro ao acessar andamento do trancamento: %w", err)
+	}
+	situacao.Solicitacoes = parseSolicitacoesTrancamento(docAndamento)
+
+	newJsessionid, newViewState, err := voltarAoPortal(jsessionid3, viewState2)
+	return situacao, newJsessionid, newViewState, err
+}
+
+// SolicitarTrancamento marca a turma, preenche a justificativa e confirma a solicitação.
+// Quem chama deve ter exigido a confirmação explícita do usuário: trancamento não se desfaz pela API.
+func SolicitarTrancamento(turmaId, justificativa, senha string, jsessionid string, viewState string) (string, string, string, error) {
+	if strings.TrimSpace(justificativa) == "" {
+		return "", jsessionid, viewState, fmt.Errorf("%w: a justificativa é obrigatória", ErrRequisicaoInvalida)
+	}
+
+	doc, jsessionid1, viewState1, err := navegarMenuPortal(ACAO_SOLICITAR_TRANCAMENTO, jsessionid, viewState)
+	if err != nil {
+		return "", jsessionid1, viewState, fmt.Errorf("erro ao acessar solicitação de trancamento: %w", err)
+	}
+	if _, erros := parseMensagensSigaa(doc); len(erros) > 0 {
+		return "", jsessionid1, viewState, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+	}
+
+	var turma TurmaTrancamento
+	for _, t := range parseTurmasTrancamento(doc) {
+		if t.Id == turmaId {
+			turma = t
+			break
+		}
+	}
+	if turma.Id == "" {
+		return "", jsessionid1, viewState, fmt.Errorf("%w: turma %s não encontrada entre as matriculadas", ErrRequisicaoInvalida, turmaId)
+	}
+	if !turma.Elegivel {
+		return "", jsessionid1, viewState, fmt.Errorf("%w: a turma %s não pode ser trancada", ErrRequisicaoInvalida, turma.ComponenteNome)
+	}
+
+	form := doc.Find("input[name='" + turma.checkbox + "']").First().Closest("form")
+	payload := formPayload(form)
+	payload.Set("javax.faces.ViewState", viewState1)
+	payload.Set(turma.checkbox, turma.Id)
+	if name := findTextFieldName(form, "justificativa", "motivo", "observac"); name != "" {
+		payload.Set(name, justificativa)
+	} else if name := form.Find("textarea[name]").First().AttrOr("name", ""); name != "" {
+		payload.Set(name, justificativa)
+	}
+	botao := ""
+	for _, rotulo := range []string{"Próximo", "Solicitar", "Continuar"} {
+		if botao = findButtonName(form, rotulo); botao != "" {
+			payload.Set(botao, form.Find("[name='"+botao+"']").AttrOr("value", rotulo))
+			break
+		}
+	}
+	if botao == "" {
+		return "", jsessionid1, viewState, fmt.Errorf("botão para solicitar o trancamento não encontrado")
+	}
+
+	docConfirmacao, jsessionid2, err := postSigaaForm(resolveActionUrl(URL_PORTAL_DISCENTE, form.AttrOr("action", "")), jsessionid1, URL_PORTAL_DISCENTE, payload)
+	if err != nil {
+		return "", jsessionid1, viewState, fmt.Errorf("erro ao solicitar trancamento: %w", err)
+	}
+	if _, erros := parseMensagensSigaa(docConfirmacao); len(erros) > 0 {
+		return "", jsessionid2, viewState, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+	}
+	viewState2, err := parseViewState(docConfirmacao, "confirmação do trancamento")
+	if err != nil {
+		return "", jsessionid2, viewState, err
+	}
+
+	// Tela de resumo: o SIGAA pode pedir a senha antes de registrar a solicitação
+	formConfirmacao := docConfirmacao.Find("form").FilterFunction(func(i int, f *goquery.Selection) bool {
+		return findButtonName(f, "Confirmar") != ""
+	}).First()
+	if formConfirmacao.Length() == 0 {
+		return "", jsessionid2, viewState, fmt.Errorf("botão 'Confirmar' não encontrado no resumo do trancamento")
+	}
+	payloadConfirmacao := formPayload(formConfirmacao)
+	payloadConfirmacao.Set("javax.faces.ViewState", viewState2)
+	if name := findFieldName(formConfirmacao, "senha"); name != "" {
+		if senha == "" {
+			return "", jsessionid2, viewState, fmt.Errorf("%w: o SIGAA exige a senha para confirmar o trancamento", ErrRequisicaoInvalida)
+		}
+		payloadConfirmacao.Set(name, senha)
+	}
+	botaoConfirmar := findButtonName(formConfirmacao, "Confirmar")
+	payloadConfirmacao.Set(botaoConfirmar, formConfirmacao.Find("[name='"+botaoConfirmar+"']").AttrOr("value", "Confirmar"))
+
+	docResultado, jsessionid3, err := postSigaaForm(resolveActionUrl(URL_PORTAL_DISCENTE, formConfirmacao.AttrOr("action", "")), jsessionid2, URL_PORTAL_DISCENTE, payloadConfirmacao)
+	if err != nil {
+		return "", jsessionid2, viewState, fmt.Errorf("erro ao confirmar trancamento: %w", err)
+	}
+	info, erros := parseMensagensSigaa(docResultado)
+	if len(erros) > 0 {
+		return "", jsessionid3, viewState, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+	}
+
+	newJsessionid, newViewState, err := voltarAoPortalAposEscrita(jsessionid3, viewState)
+	return strings.Join(info, " "), newJsessionid, newViewState, err
+}
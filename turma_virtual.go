@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// navegarMenuTurma clica no item do menu lateral da turma virtual (formMenu) com o rótulo informado
+func navegarMenuTurma(doc *goquery.Document, item, jsessionid, viewState string) (*goquery.Document, string, string, error) {
+	form := doc.Find("form#formMenu")
+	if form.Length() == 0 {
+		return nil, jsessionid, viewState, fmt.Errorf("não foi possível encontrar o menu da turma virtual")
+	}
+
+	payload := formPayload(form)
+	payload.Set("formMenu", "formMenu")
+	payload.Set("javax.faces.ViewState", viewState)
+
+	found := false
+	form.Find("[id]").EachWithBreak(func(i int, el *goquery.Selection) bool {
+		if normalizeText(el.Text()) != normalizeText(item) {
+			return true
+		}
+		onclick := el.AttrOr("onclick", "")
+		if onclick == "" {
+			onclick = el.Find("[onclick]").First().AttrOr("onclick", "")
+		}
+		if strings.Contains(onclick, "jsfcljs") {
+			for key, values := range jsfParams(onclick) {
+				payload[key] = values
+			}
+		} else {
+			id := el.AttrOr("id", "")
+			payload.Set(id, id)
+		}
+		found = true
+		return false
+	})
+	if !found {
+		return nil, jsessionid, viewState, fmt.Errorf("item '%s' não encontrado no menu da turma virtual", item)
+	}
+
+	docItem, newJsessionid, err := postSigaaForm(resolveActionUrl(URL_FREQUENCIA, form.AttrOr("action", "")), jsessionid, URL_FREQUENCIA, payload)
+	if err != nil {
+		return nil, jsessionid, viewState, fmt.Errorf("erro ao acessar '%s' na turma virtual: %w", item, err)
+	}
+
+	newViewState, err := parseViewState(docItem, item)
+	if err != nil {
+		return docItem, newJsessionid, "", err
+	}
+	return docItem, newJsessionid, newViewState, nil
+}
+
+// abrirMenuTurma entra na turma e abre o item do menu, mantendo a sessão dentro da turma virtual
+func abrirMenuTurma(turma TurmaData, item, jsessionid, viewState string) (*goquery.Document, string, string, error) {
+	docTurma, jsessionid1, err := acessarTurmaVirtual(turma, jsessionid, viewState)
+	if err != nil {
+		return nil, jsessionid, viewState, err
+	}
+	viewState1, err := parseViewState(docTurma, "turma_"+turma.Nome)
+	if err != nil {
+		return nil, jsessionid1, viewState, err
+	}
+	return navegarMenuTurma(docTurma, item, jsessionid1, viewState1)
+}
+
+// visitarMenuTurma entra na turma, abre o item do menu e volta ao portal,
+// para que o viewState devolvido continue servindo às demais rotas
+func visitarMenuTurma(turma TurmaData, item, jsessionid, viewState string) (*goquery.Document, string, string, error) {
+	docTurma, jsessionid1, err := acessarTurmaVirtual(turma, jsessionid, viewState)
+	if err != nil {
+		return nil, jsessionid, viewState, err
+	}
+	viewState1, err := parseViewState(docTurma, "turma_"+turma.Nome)
+	if err != nil {
+		return nil, jsessionid1, viewState, err
+	}
+
+	docItem, jsessionid2, _, err := navegarMenuTurma(docTurma, item, jsessionid1, viewState1)
+	if err != nil {
+		return nil, jsessionid1, viewState, err
+	}
+
+	_, jsessionid3, viewState3, err := getPaginaPortal(jsessionid2)
+	if err != nil {
+		return docItem, jsessionid2, viewState, fmt.Errorf("erro ao voltar para o portal principal: %w", err)
+	}
+	return docItem, jsessionid3, viewState3, nil
+}
+
+// submeterFormularioTurma envia um formulário da turma virtual, traduz as mensagens de erro do SIGAA
+// e volta ao portal. Aceito o envio, uma falha na volta vem marcada com ErrRetornoPortal.
+func submeterFormularioTurma(form *goquery.Selection, payload url.Values, jsessionid, viewState string) (string, string, string, error) {
+	docResultado, jsessionid1, err := postSigaaForm(resolveActionUrl(URL_FREQUENCIA, form.AttrOr("action", "")), jsessionid, URL_FREQUENCIA, payload)
+	if err != nil {
+		return "", jsessionid, viewState, fmt.Errorf("erro ao enviar formulário: %w", err)
+	}
+	info, erros := parseMensagensSigaa(docResultado)
+	if len(erros) > 0 {
+		return "", jsessionid1, viewState, fmt.Errorf("%w: %s", ErrValidacaoSigaa, strings.Join(erros, "; "))
+	}
+
+	newJsessionid, newViewState, err := voltarAoPortalAposEscrita(jsessionid1, viewState)
+	return strings.Join(info, " "), newJsessionid, newViewState, err
+}
+
+func GetParticipantes(turma TurmaData, jsessionid string, viewState string) ([]Participante, string, string, error) {
+	doc, newJsessionid, newViewState, err := visitarMenuTurma(turma, "Participantes", jsessionid, viewState)
+	if err != nil {
+		return nil, newJsessionid, newViewState, err
+	}
+	return parseParticipantes(doc), newJsessionid, newViewState, nil
+}
+
+func parseParticipantes(doc *goquery.Document) []Participante {
+	participantes := []Participante{}
+
+	doc.Find("table.participantes").Each(func(i int, table *goquery.Selection) {
+		// A página separa docentes e discentes em fieldsets com legenda própria
+		legenda := normalizeText(table.Closest("fieldset").Find("legend").First().Text())
+		tipo := "discente"
+		if strings.Contains(legenda, "docente") {
+			tipo = "docente"
+		}
+
+		table.Find("td").Each(func(j int, td *goquery.Selection) {
+			nome := strings.TrimSpace(td.Find("strong").First().Text())
+			if nome == "" {
+				return
+			}
+
+			participante := Participante{Nome: nome, Tipo: tipo}
+			for _, linha := range textLines(td) {
+				chave, valor, ok := strings.Cut(linha, ":")
+				if !ok {
+					continue
+				}
+				valor = strings.TrimSpace(valor)
+				switch normalizeText(chave) {
+				case "curso":
+					participante.Curso = valor
+				case "matricula":
+					participante.Matricula = valor
+				case "e-mail", "email":
+					participante.Email = valor
+				}
+			}
+			if src, exists := td.Prev().Find("img").Attr("src"); exists {
+				participante.FotoUrl = resolveActionUrl(URL_FREQUENCIA, src)
+			}
+
+			participantes = append(participantes, participante)
+		})
+	})
+
+	return participantes
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const URL_TURMAS_PUBLICAS = "https://sigs.ufrpe.br/sigaa/public/turmas/listar.jsf"
+
+// Códigos de horário do SIGAA: dias (2-7), turno (M, T ou N) e horários. Ex: 24M12
+var reHorario = regexp.MustCompile(`\b[2-7]+[MTN][1-7]+\b`)
+
+// BuscarTurmasAbertas usa a consulta pública de turmas do SIGAA, que não depende de login
+func BuscarTurmasAbertas(filtro FiltroTurmasAbertas) ([]TurmaAberta, error) {
+	doc, jsessionid, err := doSigaaRequest("GET", URL_TURMAS_PUBLICAS, "", "", nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar consulta pública de turmas: %w", err)
+	}
+
+	form := doc.Find("form#formTurma")
+	if form.Length() == 0 {
+		return nil, fmt.Errorf("não foi possível encontrar o formulário de consulta de turmas")
+	}
+	actionPath, exists := form.Attr("action")
+	if !exists {
+		return nil, fmt.Errorf("não foi possível encontrar a action do formulário de consulta de turmas")
+	}
+
+	payload := formPayload(form)
+
+	nivel := filtro.Nivel
+	if nivel == "" {
+		nivel = "G"
+	}
+	if name := findFieldName(form, "nivel"); name != "" {
+		payload.Set(name, nivel)
+	}
+	if name := findFieldName(form, "inputAno"); name != "" {
+		payload.Set(name, filtro.Ano)
+	}
+	if name := findFieldName(form, "inputPeriodo"); name != "" {
+		payload.Set(name, filtro.Periodo)
+	}
+	if filtro.Departamento != "" {
+		name := findFieldName(form, "depto", "unidade")
+		value, ok := selectOptionValue(form.Find("select[name='"+name+"']"), filtro.Departamento)
+		if name == "" || !ok {
+			return nil, fmt.Errorf("departamento não encontrado: %s", filtro.Departamento)
+		}
+		payload.Set(name, value)
+	}
+	if filtro.Componente != "" {
+		if name := findFieldName(form, "codigo", "componente", "disciplina"); name != "" {
+			payload.Set(name, strings.ToUpper(filtro.Componente))
+		}
+	}
+	if filtro.Docente != "" {
+		if name := findFieldName(form, "docente", "professor"); name != "" {
+			payload.Set(name, filtro.Docente)
+		}
+	}
+
+	botaoBuscar := findButtonName(form, "Buscar")
+	if botaoBuscar == "" {
+		return nil, fmt.Errorf("não foi possível encontrar o botão 'Buscar' da consulta de turmas")
+	}
+	payload.Set(botaoBuscar, "Buscar")
+
+	docResultado, _, err := postSigaaForm(resolveActionUrl(URL_TURMAS_PUBLICAS, actionPath), jsessionid, URL_TURMAS_PUBLICAS, payload)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar turmas abertas: %w", err)
+	}
+
+	return parseTurmasAbertas(docResultado), nil
+}
+
+func parseTurmasAbertas(doc *goquery.Document) []TurmaAberta {
+	turmas := []TurmaAberta{}
+
+	table := doc.Find("table.listagem").First()
+	headers := tableHeaders(table)
+
+	var componenteCodigo, componenteNome string
+	table.Find("tbody tr").Each(func(i int, row *goquery.Selection) {
+		cells := row.Find("td")
+		if row.HasClass("agrupador") || cells.Length() == 1 {
+			// Ex: "06232 - CÁLCULO NUMÉRICO (60h)"
+			partes := strings.SplitN(strings.TrimSpace(cells.First().Text()), " - ", 2)
+			componenteCodigo = strings.TrimSpace(partes[0])
+			componenteNome = ""
+			if len(partes) > 1 {
+				componenteNome = strings.Join(strings.Fields(partes[1]), " ")
+			}
+			return
+		}
+		if cells.Length() < len(headers) {
+			return
+		}
+
+		turma := TurmaAberta{
+			ComponenteCodigo: componenteCodigo,
+			ComponenteNome:   componenteNome,
+			Codigo:           cellByHeader(headers, cells, "turma", "codigo"),
+			Periodo:          cellByHeader(headers, cells, "ano-periodo", "periodo"),
+			Docente:          cellByHeader(headers, cells, "docente"),
+			Horarios:         reHorario.FindAllString(cellByHeader(headers, cells, "horario"), -1),
+			Local:            cellByHeader(headers, cells, "local"),
+			Capacidade:       parseInt(cellByHeader(headers, cells, "capacidade", "ofertadas")),
+			Matriculados:     parseInt(cellByHeader(headers, cells, "matriculados", "ocupadas")),
+		}
+		if turma.Codigo == "" && turma.Docente == "" {
+			return
+		}
+		turmas = append(turmas, turma)
+	})
+
+	return turmas
+}